@@ -0,0 +1,319 @@
+// Package analyzer provides go/analysis analyzer which reports
+// mistakes in struct tags of configuration structs used with cfg2env.
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/hasansino/cfg2env"
+)
+
+const doc = `check struct tags of cfg2env configuration structs
+
+Struct is considered to be configuration if any of its fields, including
+fields of nested structs, has environment variable tag. Analyzer reports
+malformed multi-line tags, leaf fields without environment variable tag,
+duplicate environment variable names, defaults which can not be parsed
+as field type and tags unknown to cfg2env.`
+
+// Analyzer reports mistakes in struct tags of configuration structs
+var Analyzer = &analysis.Analyzer{
+	Name: "cfg2env",
+	Doc:  doc,
+	Run:  run,
+}
+
+var (
+	envTag      string
+	defaultTag  string
+	descTag     string
+	groupTag    string
+	secretTag   string
+	requiredTag string
+	exampleTag  string
+	deprecTag   string
+	aliasesTag  string
+	dependsTag  string
+	controlTag  string
+	fileTag     string
+	extraTags   string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&envTag, "env-tag", "env", "environment variable name tag")
+	Analyzer.Flags.StringVar(&defaultTag, "default-tag", "default", "default value tag")
+	Analyzer.Flags.StringVar(&descTag, "desc-tag", "desc", "description tag")
+	Analyzer.Flags.StringVar(&groupTag, "group-tag", "group", "nested group title tag")
+	Analyzer.Flags.StringVar(&secretTag, "secret-tag", "secret", "secret field tag")
+	Analyzer.Flags.StringVar(&requiredTag, "required-tag", "required", "required field tag")
+	Analyzer.Flags.StringVar(&exampleTag, "example-tag", "example", "example value tag")
+	Analyzer.Flags.StringVar(&deprecTag, "deprecated-tag", "deprecated", "deprecation note tag")
+	Analyzer.Flags.StringVar(&aliasesTag, "aliases-tag", "aliases", "former variable names tag")
+	Analyzer.Flags.StringVar(&dependsTag, "depends-on-tag", "dependsOn", "condition tag")
+	Analyzer.Flags.StringVar(&controlTag, "control-tag", "cfg2env", "export control tag, e.g. cfg2env:\"skip\"")
+	Analyzer.Flags.StringVar(&fileTag, "file-tag", "file", "output file tag")
+	Analyzer.Flags.StringVar(&extraTags, "extra-tags", "", "comma-separated list of other known tags")
+}
+
+// New returns analyzers for golangci-lint plugin system,
+// it is exposed by cmd/cfg2env-golangci plugin
+func New(conf any) ([]*analysis.Analyzer, error) {
+	return []*analysis.Analyzer{Analyzer}, nil
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	c := &checker{
+		pass:     pass,
+		known:    knownTags(),
+		reported: make(map[string]bool),
+	}
+
+	for _, f := range pass.Files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				obj, ok := pass.TypesInfo.Defs[spec.(*ast.TypeSpec).Name]
+				if !ok || obj == nil {
+					continue
+				}
+				st, ok := obj.Type().Underlying().(*types.Struct)
+				if !ok || !c.isConfig(st) {
+					continue
+				}
+				c.check(st, make(map[string]*types.Var))
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// parseTag parses multi-line struct tag into map, pairs parsed
+// before syntax error are returned as well
+func parseTag(tag string) (map[string]string, error) {
+	tags := make(map[string]string)
+	pairs, err := cfg2env.MultilineStructTag(tag).Parse()
+	for _, pair := range pairs {
+		if _, ok := tags[pair.Key]; ok && err == nil {
+			err = fmt.Errorf("duplicate key %s", pair.Key)
+		}
+		tags[pair.Key] = pair.Value
+	}
+	return tags, err
+}
+
+// knownTags returns set of tag keys understood by cfg2env
+func knownTags() map[string]bool {
+	known := map[string]bool{
+		envTag: true, defaultTag: true, descTag: true, groupTag: true, secretTag: true, requiredTag: true,
+		exampleTag: true, deprecTag: true, aliasesTag: true, dependsTag: true,
+		controlTag: true, fileTag: true,
+	}
+	for _, tag := range strings.Split(extraTags, ",") {
+		if tag = strings.TrimSpace(tag); len(tag) > 0 {
+			known[tag] = true
+		}
+	}
+	return known
+}
+
+type checker struct {
+	pass     *analysis.Pass
+	known    map[string]bool
+	reported map[string]bool
+}
+
+// reportf reports diagnostic once, since nested named structs
+// are checked both on their own and as part of enclosing struct
+func (c *checker) reportf(pos token.Pos, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	key := fmt.Sprintf("%d:%s", pos, msg)
+	if c.reported[key] {
+		return
+	}
+	c.reported[key] = true
+	c.pass.Reportf(pos, "%s", msg)
+}
+
+// isConfig reports whether struct or any of nested structs has environment variable tag
+func (c *checker) isConfig(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if nested, ok := st.Field(i).Type().Underlying().(*types.Struct); ok {
+			if c.isConfig(nested) {
+				return true
+			}
+			continue
+		}
+		if tags, _ := parseTag(st.Tag(i)); len(tags[envTag]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// check reports mistakes in struct fields, names collects environment
+// variable names seen so far in configuration struct
+func (c *checker) check(st *types.Struct, names map[string]*types.Var) {
+	for i := 0; i < st.NumFields(); i++ {
+		var (
+			field = st.Field(i)
+			local = field.Pkg() == c.pass.Pkg
+		)
+
+		if !field.Exported() {
+			continue
+		}
+
+		tags, err := parseTag(st.Tag(i))
+		if err != nil && local {
+			c.reportf(field.Pos(), "malformed tag of field %s: %v", field.Name(), err)
+		}
+
+		if local {
+			c.checkControl(field, tags[controlTag])
+		}
+
+		// skipped fields and structs are not exported
+		if isSkipped(tags) {
+			continue
+		}
+
+		if nested, ok := field.Type().Underlying().(*types.Struct); ok {
+			c.check(nested, names)
+			continue
+		}
+
+		if !local {
+			if env := tags[envTag]; len(env) > 0 {
+				names[env] = field
+			}
+			continue
+		}
+
+		env := tags[envTag]
+		if len(env) == 0 {
+			if err == nil {
+				c.reportf(field.Pos(), "field %s has no %s tag", field.Name(), envTag)
+			}
+			continue
+		}
+
+		if prev, ok := names[env]; ok {
+			c.reportf(field.Pos(), "duplicate environment variable %s, already used by field %s", env, prev.Name())
+		} else {
+			names[env] = field
+		}
+
+		for key, value := range tags {
+			isDefault := key == defaultTag || strings.HasPrefix(key, defaultTag+".")
+			if !c.known[key] && !isDefault {
+				c.reportf(field.Pos(), "unknown tag %s of field %s", key, field.Name())
+			}
+			if isDefault && len(value) > 0 {
+				if err := parseDefault(field.Type(), value); err != nil {
+					c.reportf(field.Pos(), "default value of field %s can not be parsed: %v", field.Name(), err)
+				}
+			}
+		}
+
+		if example := tags[exampleTag]; len(example) > 0 {
+			if err := parseDefault(field.Type(), example); err != nil {
+				c.reportf(field.Pos(), "example value of field %s can not be parsed: %v", field.Name(), err)
+			}
+		}
+	}
+}
+
+// isSkipped reports whether field is skipped with `env:"-"` or `cfg2env:"skip"`
+func isSkipped(tags map[string]string) bool {
+	if tags[envTag] == "-" {
+		return true
+	}
+	for _, o := range strings.Split(tags[controlTag], ",") {
+		if strings.TrimSpace(o) == cfg2env.ControlSkip {
+			return true
+		}
+	}
+	return false
+}
+
+// checkControl reports unknown options of control tag
+func (c *checker) checkControl(field *types.Var, control string) {
+	if len(control) == 0 {
+		return
+	}
+	for _, o := range strings.Split(control, ",") {
+		switch o = strings.TrimSpace(o); o {
+		case cfg2env.ControlSkip, cfg2env.ControlHidden:
+		default:
+			c.reportf(field.Pos(), "unknown option %s of %s tag of field %s", o, controlTag, field.Name())
+		}
+	}
+}
+
+// parseDefault checks that default value can be parsed as value of given type,
+// slices are expected to contain comma-separated values
+func parseDefault(t types.Type, value string) error {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration" {
+			_, err := time.ParseDuration(value)
+			return err
+		}
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		for _, v := range strings.Split(value, ",") {
+			if err := parseDefault(u.Elem(), strings.TrimSpace(v)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *types.Basic:
+		var err error
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			_, err = strconv.ParseBool(value)
+		case u.Info()&types.IsUnsigned != 0:
+			_, err = strconv.ParseUint(value, 10, basicBits(u))
+		case u.Info()&types.IsInteger != 0:
+			_, err = strconv.ParseInt(value, 10, basicBits(u))
+		case u.Info()&types.IsFloat != 0:
+			_, err = strconv.ParseFloat(value, basicBits(u))
+		}
+		if err != nil {
+			return err.(*strconv.NumError).Err
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// basicBits returns bit size of numeric type, 0 for platform dependent size
+func basicBits(b *types.Basic) int {
+	switch b.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,21 @@
+package analyzer
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "config")
+}
+
+func TestParseDefaultInt64(t *testing.T) {
+	assert.NoError(t, parseDefault(types.Typ[types.Int64], "9000000000"))
+	assert.NoError(t, parseDefault(types.Typ[types.Uint64], "18000000000000000000"))
+	assert.Error(t, parseDefault(types.Typ[types.Int32], "9000000000"))
+	assert.Equal(t, 64, basicBits(types.Typ[types.Int64]))
+	assert.Equal(t, 64, basicBits(types.Typ[types.Uint64]))
+}
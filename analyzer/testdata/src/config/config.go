@@ -0,0 +1,50 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+type Config struct {
+	sync.RWMutex
+
+	A string `env:"A" default:"a"
+		desc:"Multi-line tags are fine"`
+	B int           `env:"B" default:"forty-two"`                // want `default value of field B can not be parsed: invalid syntax`
+	C int8          `env:"C" default:"300"`                      // want `default value of field C can not be parsed: value out of range`
+	D time.Duration `env:"D" default:"10 parsecs"`               // want `default value of field D can not be parsed: .*`
+	E []uint        `env:"E" default:"1, 2, -3"`                 // want `default value of field E can not be parsed: invalid syntax`
+	F bool          `env:"F" default:"true" validate:"required"` // want `unknown tag validate of field F`
+	G string        // want `field G has no env tag`
+	H string        `env:"A"`             // want `duplicate environment variable A, already used by field A`
+	I string        `env:"I" default:"i`  // want `malformed tag of field I: line 1, offset 16: unterminated value of key "default"`
+	J string        `env:"J" default "j"` // want `malformed tag of field J: line 1, offset 16: unexpected character '"' after key "default", expected colon`
+	L string        `env:"L" env:"M"`     // want `malformed tag of field L: duplicate key env`
+	K string        `env:"K" default:j`   // want `malformed tag of field K: line 1, offset 16: value of key "default" is not quoted`
+
+	internal string
+	Nested   struct {
+		Foo string `env:"NESTED_FOO" secret:"true" required:"true" dependsOn:"A"`
+		Bar string `env:"B"` // want `duplicate environment variable B, already used by field B`
+	}
+	Database Database
+	Skipped  string `env:"-"`
+	Skipped2 string `env:"-"`
+	Ignored  struct {
+		X string
+	} `cfg2env:"skip"`
+	Token   string `env:"TOKEN" cfg2env:"hiden" file:"secrets.env"`                            // want `unknown option hiden of cfg2env tag of field Token`
+	Level   int    `env:"LEVEL" default:"1" default.prod:"high"`                               // want `default value of field Level can not be parsed: invalid syntax`
+	Workers int    `env:"WORKERS" example:"many" aliases:"THREADS" deprecated:"use POOL_SIZE"` // want `example value of field Workers can not be parsed: invalid syntax`
+}
+
+type Database struct {
+	DSN  string `env:"DSN"`
+	Port int    `env:"PORT" default:"port"` // want `default value of field Port can not be parsed: invalid syntax`
+}
+
+// NotConfig has no env tags and is not checked
+type NotConfig struct {
+	Name string
+	Age  int `json:"age"`
+}
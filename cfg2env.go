@@ -3,11 +3,12 @@ package cfg2env
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"os"
 	"reflect"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 )
 
 // default values
@@ -16,13 +17,31 @@ const (
 	_defEnvironmentTagName  = `env`
 	_defDefaultValueTagName = `default`
 	_defDescriptionTagName  = `desc`
+	_defGroupTagName        = `group`
+	_defSecretTagName       = `secret`
+	_defRequiredTagName     = `required`
+	_defExampleTagName      = `example`
+	_defDeprecatedTagName   = `deprecated`
+	_defAliasesTagName      = `aliases`
+	_defDependsOnTagName    = `dependsOn`
+	_defControlTagName      = `cfg2env`
+	_defFileTagName         = `file`
 	_defFileName            = `.env`
+	_defKubernetesName      = `config`
+	_defRootGroupTitle      = `Root`
+	_defSecretPlaceholder   = `CHANGE_ME`
+	_defRequiredPlaceholder = `TODO`
 )
 
 var (
 	_defExcludedFields = []string{
 		"RWMutex",
 	}
+	_defDescriptionLayout = []DescriptionPart{
+		DescriptionFieldName,
+		DescriptionGoType,
+		DescriptionText,
+	}
 )
 
 // Exporter is configuration file parser and exporter
@@ -31,10 +50,50 @@ type Exporter struct {
 	environmentTagName  string
 	defaultValueTagName string
 	descriptionTagName  string
+	groupTagName        string
+	secretTagName       string
+	requiredTagName     string
+	exampleTagName      string
+	deprecatedTagName   string
+	aliasesTagName      string
+	dependsOnTagName    string
+	controlTagName      string
+	fileTagName         string
 	fileName            string
 	excludedFields      []string
+	defaultExcluded     bool
+	strictTags          bool
+	commentWidth        int
+	commentIndent       bool
+	commentFormatter    func(s string) string
+	commentMarkdown     bool
+	template            string
+	descriptionLayout   []DescriptionPart
+	omitEmptyGroups     bool
+	groupOrder          GroupOrder
+	rootGroupTitle      string
+	profile             string
+	fieldValues         bool
+	example             bool
+	exampleFile         bool
+	commentOutDisabled  bool
+	features            map[string]bool
+	includeFilters      []FieldFilter
+	excludeFilters      []FieldFilter
+	splitByGroup        bool
 	extraEntries        map[string]interface{}
 	extraTags           []string
+	secretPlaceholder   string
+	requiredPlaceholder string
+
+	kubernetesName         string
+	kubernetesNamespace    string
+	kubernetesLabels       map[string]string
+	kubernetesSecretBase64 bool
+
+	composeListSyntax bool
+
+	helmValuesPrefix string
 }
 
 // cfgItem represents single item of configuration
@@ -42,19 +101,32 @@ type Exporter struct {
 // which will be recursively parsed
 type cfgItem struct {
 	nestedGroup bool
+	resumed     bool // group header repeated after nested groups
 	comment     string
 	envVarName  string
 	defValue    string
+	fieldPath   string
+	secret      bool
+	required    bool
+	deprecated  string     // deprecation note
+	aliases     []string   // former names of variable
+	dependsOn   string     // condition of variable or group, see dependsOn tag
+	disabled    bool       // condition is not met by default values
+	hidden      bool       // variable or group is omitted from rendered output
+	file        string     // file variable or group is routed to, see file tag
+	field       *fieldInfo // source field of variable definition
+	err         error      // set if field can not be exported
 }
 
 // New creates new exporter with provided options
 func New(opts ...Option) *Exporter {
 	e := &Exporter{
-		headerText:     _defHeaderText,
-		excludedFields: make([]string, 0),
-		extraEntries:   make(map[string]interface{}),
+		headerText:       _defHeaderText,
+		excludedFields:   make([]string, 0),
+		defaultExcluded:  true,
+		extraEntries:     make(map[string]interface{}),
+		kubernetesLabels: make(map[string]string),
 	}
-	e.excludedFields = append(e.excludedFields, _defExcludedFields...)
 	for _, o := range opts {
 		o(e) // apply all options if needed
 	}
@@ -72,99 +144,116 @@ func (e *Exporter) defaults() {
 	if len(e.descriptionTagName) == 0 {
 		e.descriptionTagName = _defDescriptionTagName
 	}
+	if len(e.groupTagName) == 0 {
+		e.groupTagName = _defGroupTagName
+	}
+	if len(e.rootGroupTitle) == 0 {
+		e.rootGroupTitle = _defRootGroupTitle
+	}
+	if len(e.secretTagName) == 0 {
+		e.secretTagName = _defSecretTagName
+	}
+	if len(e.requiredTagName) == 0 {
+		e.requiredTagName = _defRequiredTagName
+	}
+	if len(e.exampleTagName) == 0 {
+		e.exampleTagName = _defExampleTagName
+	}
+	if len(e.deprecatedTagName) == 0 {
+		e.deprecatedTagName = _defDeprecatedTagName
+	}
+	if len(e.aliasesTagName) == 0 {
+		e.aliasesTagName = _defAliasesTagName
+	}
+	if len(e.dependsOnTagName) == 0 {
+		e.dependsOnTagName = _defDependsOnTagName
+	}
+	if len(e.controlTagName) == 0 {
+		e.controlTagName = _defControlTagName
+	}
+	if len(e.fileTagName) == 0 {
+		e.fileTagName = _defFileTagName
+	}
 	if len(e.fileName) == 0 {
 		e.fileName = _defFileName
 	}
+	if len(e.descriptionLayout) == 0 {
+		e.descriptionLayout = _defDescriptionLayout
+	}
+	if len(e.template) == 0 {
+		e.template = DefaultTemplate
+	}
+	if len(e.secretPlaceholder) == 0 {
+		e.secretPlaceholder = _defSecretPlaceholder
+	}
+	if len(e.requiredPlaceholder) == 0 {
+		e.requiredPlaceholder = _defRequiredPlaceholder
+	}
+	if len(e.kubernetesName) == 0 {
+		e.kubernetesName = _defKubernetesName
+	}
 }
 
-// ToFile exports data to file, file path can be set with WithExportedFileName
+// ToFile exports data to file, file path can be set with WithExportedFileName,
+// if variables are split into several files (see ExportFiles) all of them
+// are written, example file is written alongside if WithExampleFile is enabled
 func (e *Exporter) ToFile(cfg interface{}) error {
-	f, err := os.Create(e.fileName)
+	files, err := e.ExportFiles(cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create file: %v", err)
+		return err
+	}
+	if err := e.toFiles(files); err != nil {
+		return err
+	}
+	if e.exampleFile {
+		return e.toExampleFile(cfg)
 	}
+	return nil
+}
 
-	data, err := e.Export(cfg)
+// writeFile creates or overwrites file with given data
+func writeFile(name string, data []byte) error {
+	f, err := os.Create(name)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to create file: %v", err)
 	}
+	defer f.Close()
 
 	if _, err := f.Write(data); err != nil {
 		return fmt.Errorf("failed to write to file: %v", err)
 	}
 
 	if err := f.Sync(); err != nil {
-		log.Fatalf("failed to sync file: %v", err)
+		return fmt.Errorf("failed to sync file: %v", err)
 	}
 
 	return nil
 }
 
 // Export exports struct to readable and structured .env format
+// cfg is either pointer to configuration struct or *Struct description
+// Layout is defined by template, see DefaultTemplate and WithTemplate
 func (e *Exporter) Export(cfg interface{}) ([]byte, error) {
-	buff := new(bytes.Buffer)
-	exported := e.reflectCfg(cfg, ``)
-
-	if len(e.headerText) > 0 {
-		if _, err := buff.WriteString(e.headerText + "\n\n"); err != nil {
-			return nil, fmt.Errorf("failed to write to file: %v", err)
-		}
+	exported, err := e.items(cfg)
+	if err != nil {
+		return nil, err
 	}
+	return e.render(exported)
+}
 
-	if len(e.extraEntries) > 0 {
-		if _, err := buff.WriteString("# Extra pre-declared entries\n"); err != nil {
-			return nil, fmt.Errorf("failed to write to buffer: %v", err)
-		}
-		for k, v := range e.extraEntries {
-			if _, err := fmt.Fprintf(buff, "%s=%v\n", k, v); err != nil {
-				return nil, fmt.Errorf("failed to write to buffer: %v", err)
-			}
-		}
-		// avoid double newline if nothing to export
-		if len(exported) > 0 {
-			if _, err := buff.WriteString("\n"); err != nil {
-				return nil, fmt.Errorf("failed to write to buffer: %v", err)
-			}
-		}
+// render renders exported items with template, headers of parent groups
+// are repeated after nested groups, see resumeGroups
+func (e *Exporter) render(exported []cfgItem) ([]byte, error) {
+	exported = e.resumeGroups(exported)
+
+	tmpl, err := template.New("dotenv").Funcs(e.templateFuncs()).Parse(e.template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %v", err)
 	}
 
-	for i := range exported {
-		if len(exported[i].comment) > 0 {
-			// comment, either nested group or variable related
-			// ---
-			toWrite := formatComment(exported[i].comment)
-			if exported[i].nestedGroup {
-				toWrite = "#" + toWrite
-			}
-			toWrite += "\n"
-			if exported[i].nestedGroup {
-				toWrite += "\n"
-			}
-			// ---
-			_, err := buff.WriteString(toWrite)
-			if err != nil {
-				return nil, fmt.Errorf("failed to write to buffer: %v", err)
-			}
-		} else {
-			// variable=value
-			// ---
-			value := exported[i].defValue
-			if strings.Contains(exported[i].defValue, " ") {
-				value = fmt.Sprintf("\"%s\"", value)
-			}
-			// ---
-			_, err := fmt.Fprintf(buff, "%s=%s\n", exported[i].envVarName, value)
-			if err != nil {
-				return nil, fmt.Errorf("failed to write to buffer: %v", err)
-			}
-			// ---
-			if len(exported) > i+1 && exported[i+1].nestedGroup {
-				_, err := fmt.Fprintf(buff, "\n")
-				if err != nil {
-					return nil, fmt.Errorf("failed to write to buffer: %v", err)
-				}
-			}
-		}
+	buff := new(bytes.Buffer)
+	if err := tmpl.Execute(buff, e.templateModel(exported)); err != nil {
+		return nil, fmt.Errorf("failed to execute template: %v", err)
 	}
 
 	return buff.Bytes(), nil
@@ -172,6 +261,10 @@ func (e *Exporter) Export(cfg interface{}) ([]byte, error) {
 
 // reflectCfg exports struct data in structured format
 func (e *Exporter) reflectCfg(cfg interface{}, prefix string) []cfgItem {
+	if s, ok := cfg.(*Struct); ok {
+		return e.describeCfg(s, prefix)
+	}
+
 	var (
 		rt = reflect.TypeOf(cfg)
 		rv = reflect.ValueOf(cfg)
@@ -182,7 +275,7 @@ func (e *Exporter) reflectCfg(cfg interface{}, prefix string) []cfgItem {
 		rv = rv.Elem()
 	}
 
-	exported := make([]cfgItem, 0)
+	exported := e.newLevel()
 
 	for i := 0; i < rt.NumField(); i++ {
 		var (
@@ -197,59 +290,167 @@ func (e *Exporter) reflectCfg(cfg interface{}, prefix string) []cfgItem {
 		}
 
 		// skip excluded fields
-		var isExcluded bool
-		for _, n := range e.excludedFields {
-			if strings.EqualFold(strings.ToLower(field.Name), strings.ToLower(n)) {
-				isExcluded = true
-				break
-			}
-		}
-		if isExcluded {
+		isStruct := field.Type.Kind() == reflect.Struct
+		if e.isExcluded(field.Name) || e.isSkipped(MultilineStructTag(field.Tag)) ||
+			e.isFiltered(fieldPath, field.Type.String(), MultilineStructTag(field.Tag), !isStruct) {
 			continue
 		}
 
-		switch field.Type.Kind() {
-		case reflect.Struct:
-			exported = append(exported, cfgItem{
-				nestedGroup: true,
-				comment:     fieldPath,
-			})
-			exported = append(
-				exported,
-				e.reflectCfg(value.Addr().Interface(), fieldPath+".")...,
-			)
+		exported.addField(e.checkTag(fieldPath, MultilineStructTag(field.Tag))...)
+
+		switch {
+		case isStruct:
+			if nested := e.reflectCfg(value.Addr().Interface(), fieldPath+"."); e.keepGroup(nested) {
+				exported.addGroup(e.groupItem(fieldPath, MultilineStructTag(field.Tag), ``), nested...)
+			}
 		default:
-			tag := MultilineStructTag(field.Tag)
-			if envVarName := tag.Get(e.environmentTagName); len(envVarName) > 0 {
-				// variable description [field_name (type) description]
-				itemDescription := cfgItem{
-					comment: fmt.Sprintf(
-						"%s (%s)", field.Name, field.Type.String(),
-					),
-				}
-				if desc := tag.Get(e.descriptionTagName); len(desc) > 0 {
-					itemDescription.comment += " " + desc
-				}
-				exported = append(exported, itemDescription)
-
-				// extract extra tags
-				for i := range e.extraTags {
-					if v := tag.Get(e.extraTags[i]); len(v) > 0 {
-						extraTagline := fmt.Sprintf("Tag: %s -> %s", e.extraTags[i], v)
-						exported = append(exported, cfgItem{
-							comment: extraTagline,
-						})
-					}
-				}
-
-				// variable definition [variable=default_value]
+			info := fieldInfo{
+				name:     field.Name,
+				typeName: field.Type.String(),
+				kind:     underlyingType(field.Type),
+				path:     fieldPath,
+				tag:      MultilineStructTag(field.Tag),
+			}
+			if e.fieldValues {
+				info.value, info.hasValue = formatValue(value), true
+			}
+			exported.addField(e.fieldItems(info)...)
+		}
+	}
+
+	return exported.items()
+}
+
+// items exports struct data without hidden items and returns first error found in it
+func (e *Exporter) items(cfg interface{}) ([]cfgItem, error) {
+	return e.walk(cfg, false)
+}
+
+// walk exports struct data, hidden items are kept if requested
+func (e *Exporter) walk(cfg interface{}, hidden bool) ([]cfgItem, error) {
+	exported := hideItems(e.conditions(e.reflectCfg(cfg, ``)))
+	for i := range exported {
+		if exported[i].err != nil {
+			return nil, exported[i].err
+		}
+	}
+	if !hidden {
+		exported = visibleItems(exported)
+	}
+	return exported, nil
+}
+
+// checkTag returns item with error if tag is malformed and strict mode is enabled
+func (e *Exporter) checkTag(fieldPath string, tag MultilineStructTag) []cfgItem {
+	if !e.strictTags {
+		return nil
+	}
+	if _, err := tag.Parse(); err != nil {
+		return []cfgItem{{
+			fieldPath: fieldPath,
+			err:       fmt.Errorf("malformed tag of field %s: %w", fieldPath, err),
+		}}
+	}
+	return nil
+}
+
+// isExcluded reports whether field with given name should not be exported
+func (e *Exporter) isExcluded(name string) bool {
+	excluded := e.excludedFields
+	if e.defaultExcluded {
+		excluded = append(append(make([]string, 0), _defExcludedFields...), excluded...)
+	}
+	for _, n := range excluded {
+		if strings.EqualFold(strings.ToLower(name), strings.ToLower(n)) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldInfo is walker independent information about non-struct field
+type fieldInfo struct {
+	name     string             // field name
+	typeName string             // type as declared, e.g. []config.Level
+	kind     string             // underlying type, e.g. []string
+	path     string             // field path
+	tag      MultilineStructTag // field tag
+	doc      string             // description used if field has no description tag
+	value    string             // current value of field, see WithFieldValues
+	hasValue bool               // value is used instead of default value
+}
+
+// fieldItems exports single non-struct field, fields without
+// environment variable name produce no items
+func (e *Exporter) fieldItems(field fieldInfo) []cfgItem {
+	exported := make([]cfgItem, 0)
+
+	envVarName := field.tag.Get(e.environmentTagName)
+	if len(envVarName) == 0 || envVarName == "-" {
+		return exported
+	}
+
+	// variable description [field_name (type) description]
+	if description := e.description(field); len(description) > 0 {
+		exported = append(exported, cfgItem{
+			comment:   description,
+			fieldPath: field.path,
+		})
+	}
+
+	// extract extra tags, unless they are part of description
+	if !e.inlineExtraTags() {
+		for i := range e.extraTags {
+			if v := field.tag.Get(e.extraTags[i]); len(v) > 0 {
+				extraTagline := fmt.Sprintf("Tag: %s -> %s", e.extraTags[i], v)
 				exported = append(exported, cfgItem{
-					envVarName: envVarName, defValue: tag.Get(e.defaultValueTagName),
+					comment:   extraTagline,
+					fieldPath: field.path,
 				})
 			}
 		}
 	}
 
+	// deprecation and rename warnings
+	deprecated, aliases := field.tag.Get(e.deprecatedTagName), e.aliases(field.tag)
+	for _, warning := range deprecationWarnings(envVarName, deprecated, aliases) {
+		exported = append(exported, cfgItem{
+			comment:   warning,
+			fieldPath: field.path,
+		})
+	}
+
+	// condition note
+	dependsOn := field.tag.Get(e.dependsOnTagName)
+	if len(dependsOn) > 0 {
+		exported = append(exported, cfgItem{
+			comment:   conditionNote(dependsOn),
+			fieldPath: field.path,
+		})
+	}
+
+	defValue := e.defaultValue(field.tag)
+	if field.hasValue {
+		defValue = field.value
+	}
+	if e.example {
+		defValue = e.exampleValue(field.tag, defValue)
+	}
+
+	// variable definition [variable=default_value]
+	exported = append(exported, cfgItem{
+		envVarName: envVarName, defValue: defValue,
+		fieldPath:  field.path,
+		secret:     isTrue(field.tag.Get(e.secretTagName)),
+		required:   isTrue(field.tag.Get(e.requiredTagName)),
+		deprecated: deprecated,
+		aliases:    aliases,
+		dependsOn:  dependsOn,
+		hidden:     e.hasControl(field.tag, ControlHidden),
+		file:       field.tag.Get(e.fileTagName),
+		field:      &field,
+	})
+
 	return exported
 }
 
@@ -264,3 +465,9 @@ func formatComment(s string) string {
 	s = "# " + strings.ReplaceAll(s, "\n", "\n#")
 	return s
 }
+
+// isTrue reports whether tag value represents boolean true
+func isTrue(s string) bool {
+	v, err := strconv.ParseBool(s)
+	return err == nil && v
+}
@@ -1,6 +1,7 @@
 package cfg2env
 
 import (
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -185,7 +186,7 @@ func TestExportEmptyStruct(t *testing.T) {
 		WithExcludedFields("TestExcluded"),
 		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
 		WithExtraTagExtraction("validate"),
-		WithExportedFileName(".env.empty"),
+		WithExportedFileName(filepath.Join(t.TempDir(), ".env.empty")),
 	)
 
 	// -- file
@@ -246,6 +247,7 @@ func TestExport(t *testing.T) {
 		WithExcludedFields("TestExcluded"),
 		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
 		WithExtraTagExtraction("validate"),
+		WithExportedFileName(filepath.Join(t.TempDir(), ".env")),
 	)
 
 	// -- file
@@ -275,8 +277,6 @@ type testConfigNestedFirst struct {
 }
 
 func TestExportNestedFirst(t *testing.T) {
-	t.Skip()
-
 	e := New(
 		WithEnvironmentTagName("env"),
 		WithDefaultValueTagName("default"),
@@ -284,7 +284,7 @@ func TestExportNestedFirst(t *testing.T) {
 		WithExcludedFields("TestExcluded"),
 		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
 		WithExtraTagExtraction("validate"),
-		WithExportedFileName(".env.nested_first"),
+		WithExportedFileName(filepath.Join(t.TempDir(), ".env.nested_first")),
 	)
 
 	// -- file
@@ -298,6 +298,27 @@ func TestExportNestedFirst(t *testing.T) {
 		t.Error(err)
 	}
 
-	expected := "# Test Header\n\n# Extra pre-declared entries\nCOMPOSE_PROJECT_NAME=cfg2env\n\n## NestedFirst\n\n# A (string)\nNestedFirst_A=def_value_of_a\n# B (string)\nNestedFirst_B=def_value_of_b\n# C (string)\nNestedFirst_C=def_value_of_c\n\n# A (string)\nA=def_value_of_a\n# B (string)\nB=def_value_of_b\n# C (string)\nC=def_value_of_c\n"
+	expected := "# Test Header\n\n# Extra pre-declared entries\nCOMPOSE_PROJECT_NAME=cfg2env\n\n## NestedFirst\n\n# A (string)\nNestedFirst_A=def_value_of_a\n# B (string)\nNestedFirst_B=def_value_of_b\n# C (string)\nNestedFirst_C=def_value_of_c\n\n## Root\n\n# A (string)\nA=def_value_of_a\n# B (string)\nB=def_value_of_b\n# C (string)\nC=def_value_of_c\n"
 	assert.Equal(t, expected, string(d))
 }
+
+type malformedStruct struct {
+	A string `env:"A" default:"a"`
+	B string `env:"B" default:b`
+}
+
+func TestExportStrictTags(t *testing.T) {
+	// by default malformed part of tag is ignored
+	d, err := New(WithHeaderText("")).Export(new(malformedStruct))
+	assert.NoError(t, err)
+	assert.Equal(t, "# A (string)\nA=a\n# B (string)\nB=\n", string(d))
+
+	_, err = New(WithStrictTags(true)).Export(new(malformedStruct))
+	assert.EqualError(t, err, `malformed tag of field B: line 1, offset 16: value of key "default" is not quoted`)
+
+	var syntaxErr *TagSyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+
+	_, err = New(WithStrictTags(true)).ExportKubernetes(new(malformedStruct))
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+package cfg2env
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ChangeKind is kind of change between two versions of configuration
+type ChangeKind string
+
+const (
+	// ChangeAdded is new variable
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved is variable which no longer exists
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeRenamed is variable with new name, detected by aliases tag or field path
+	ChangeRenamed ChangeKind = "renamed"
+	// ChangeDefault is variable with changed default value
+	ChangeDefault ChangeKind = "default"
+)
+
+// Change is single change between two versions of configuration
+type Change struct {
+	Kind       ChangeKind `json:"kind"`
+	Name       string     `json:"name"`                  // variable name, new one for renamed variables
+	OldName    string     `json:"old_name,omitempty"`    // former name of renamed variable
+	OldDefault string     `json:"old_default,omitempty"` // default value in old version
+	NewDefault string     `json:"new_default,omitempty"` // default value in new version
+}
+
+// Changelog is list of changes between two versions of configuration
+type Changelog struct {
+	Changes []Change `json:"changes"`
+}
+
+// Changelog compares two versions of configuration, each of them is either
+// pointer to configuration struct, *Struct description or previously
+// exported *Manifest. Added and renamed variables and changed defaults are
+// listed in order of new version, followed by removed variables.
+func (e *Exporter) Changelog(from, to interface{}) (*Changelog, error) {
+	oldManifest, err := e.manifest(from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export old version: %w", err)
+	}
+	newManifest, err := e.manifest(to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export new version: %w", err)
+	}
+
+	var (
+		oldVars  = make(map[string]ManifestVariable)
+		oldPaths = make(map[string]string)
+		newNames = make(map[string]bool)
+	)
+	for _, v := range oldManifest.Variables {
+		oldVars[v.Name] = v
+		oldPaths[v.Path] = v.Name
+	}
+	for _, v := range newManifest.Variables {
+		newNames[v.Name] = true
+	}
+
+	var (
+		changelog = &Changelog{Changes: make([]Change, 0)}
+		matched   = make(map[string]bool)
+	)
+	for _, v := range newManifest.Variables {
+		prev, ok := oldVars[v.Name]
+		if !ok {
+			if name, found := renamedFrom(v, oldVars, oldPaths, newNames, matched); found {
+				prev, ok = oldVars[name], true
+				changelog.Changes = append(changelog.Changes, Change{
+					Kind: ChangeRenamed, Name: v.Name, OldName: name,
+				})
+			}
+		}
+		if !ok {
+			changelog.Changes = append(changelog.Changes, Change{
+				Kind: ChangeAdded, Name: v.Name, NewDefault: v.Default,
+			})
+			continue
+		}
+		matched[prev.Name] = true
+		if prev.Default != v.Default {
+			changelog.Changes = append(changelog.Changes, Change{
+				Kind: ChangeDefault, Name: v.Name, OldDefault: prev.Default, NewDefault: v.Default,
+			})
+		}
+	}
+	for _, v := range oldManifest.Variables {
+		if !matched[v.Name] {
+			changelog.Changes = append(changelog.Changes, Change{
+				Kind: ChangeRemoved, Name: v.Name, OldDefault: v.Default,
+			})
+		}
+	}
+
+	return changelog, nil
+}
+
+// renamedFrom finds former name of variable, first by its aliases,
+// then by field path, former name must not exist in new version
+func renamedFrom(
+	v ManifestVariable,
+	oldVars map[string]ManifestVariable,
+	oldPaths map[string]string,
+	newNames, matched map[string]bool,
+) (string, bool) {
+	candidates := append(append(make([]string, 0, len(v.Aliases)+1), v.Aliases...), oldPaths[v.Path])
+	for _, name := range candidates {
+		if _, ok := oldVars[name]; ok && !newNames[name] && !matched[name] {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// JSON renders changelog as JSON
+func (c *Changelog) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal changelog: %v", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Markdown renders changelog as Markdown, changes are grouped by kind
+func (c *Changelog) Markdown() ([]byte, error) {
+	sections := []struct {
+		kind  ChangeKind
+		title string
+		line  func(c Change) string
+	}{
+		{ChangeAdded, "Added", func(c Change) string {
+			return fmt.Sprintf("- `%s` (default `%s`)", c.Name, c.NewDefault)
+		}},
+		{ChangeRemoved, "Removed", func(c Change) string {
+			return fmt.Sprintf("- `%s`", c.Name)
+		}},
+		{ChangeRenamed, "Renamed", func(c Change) string {
+			return fmt.Sprintf("- `%s` -> `%s`", c.OldName, c.Name)
+		}},
+		{ChangeDefault, "Default changed", func(c Change) string {
+			return fmt.Sprintf("- `%s`: `%s` -> `%s`", c.Name, c.OldDefault, c.NewDefault)
+		}},
+	}
+
+	lines := make([]string, 0)
+	for _, section := range sections {
+		changes := make([]string, 0)
+		for i := range c.Changes {
+			if c.Changes[i].Kind == section.kind {
+				changes = append(changes, section.line(c.Changes[i]))
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "## "+section.title, "")
+		lines = append(lines, changes...)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "No changes")
+	}
+
+	return writeLines(lines)
+}
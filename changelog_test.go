@@ -0,0 +1,89 @@
+package cfg2env
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigChangelogV1 struct {
+	Host    string `env:"OLD_DB_HOST" default:"localhost"`
+	Port    int    `env:"DB_PORT" default:"5432"`
+	Timeout string `env:"TIMEOUT" default:"5s"`
+	Debug   bool   `env:"DEBUG"`
+	Pool    int    `env:"POOL" default:"10"`
+}
+
+type testConfigChangelogV2 struct {
+	Host     string `env:"DB_HOST" default:"localhost" aliases:"OLD_DB_HOST"`
+	Port     int    `env:"DB_PORT" default:"6432"`
+	Timeout  string `env:"TIMEOUT" default:"5s"`
+	Pool     int    `env:"POOL_SIZE" default:"20"`
+	LogLevel string `env:"LOG_LEVEL" default:"info"`
+}
+
+func TestChangelog(t *testing.T) {
+	e := New()
+
+	changelog, err := e.Changelog(new(testConfigChangelogV1), new(testConfigChangelogV2))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, []Change{
+		{Kind: ChangeRenamed, Name: "DB_HOST", OldName: "OLD_DB_HOST"},
+		{Kind: ChangeDefault, Name: "DB_PORT", OldDefault: "5432", NewDefault: "6432"},
+		{Kind: ChangeRenamed, Name: "POOL_SIZE", OldName: "POOL"},
+		{Kind: ChangeDefault, Name: "POOL_SIZE", OldDefault: "10", NewDefault: "20"},
+		{Kind: ChangeAdded, Name: "LOG_LEVEL", NewDefault: "info"},
+		{Kind: ChangeRemoved, Name: "DEBUG"},
+	}, changelog.Changes)
+
+	md, err := changelog.Markdown()
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "## Added\n\n- `LOG_LEVEL` (default `info`)\n\n"+
+		"## Removed\n\n- `DEBUG`\n\n"+
+		"## Renamed\n\n- `OLD_DB_HOST` -> `DB_HOST`\n- `POOL` -> `POOL_SIZE`\n\n"+
+		"## Default changed\n\n- `DB_PORT`: `5432` -> `6432`\n- `POOL_SIZE`: `10` -> `20`\n",
+		string(md),
+	)
+
+	js, err := changelog.JSON()
+	if err != nil {
+		t.Error(err)
+	}
+	decoded := new(Changelog)
+	assert.NoError(t, json.Unmarshal(js, decoded))
+	assert.Equal(t, changelog, decoded)
+}
+
+func TestChangelogManifest(t *testing.T) {
+	e := New()
+
+	manifest, err := e.Manifest(new(testConfigChangelogV2))
+	if err != nil {
+		t.Error(err)
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Error(err)
+	}
+
+	stored := new(Manifest)
+	assert.NoError(t, json.Unmarshal(data, stored))
+
+	changelog, err := e.Changelog(stored, new(testConfigChangelogV2))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Empty(t, changelog.Changes)
+
+	md, err := changelog.Markdown()
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "No changes\n", string(md))
+}
@@ -0,0 +1,21 @@
+// Command cfg2env-golangci is golangci-lint Go plugin checking
+// struct tags of cfg2env configuration structs.
+//
+// It has to be built as plugin:
+//
+//	go build -buildmode=plugin -o cfg2env.so github.com/hasansino/cfg2env/cmd/cfg2env-golangci
+package main
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/hasansino/cfg2env/analyzer"
+)
+
+// New is looked up by golangci-lint when plugin is loaded
+func New(conf any) ([]*analysis.Analyzer, error) {
+	return analyzer.New(conf)
+}
+
+// main is never called, plugin is loaded by golangci-lint
+func main() {}
@@ -0,0 +1,16 @@
+// Command cfg2env-lint checks struct tags of cfg2env configuration structs.
+//
+// It can be run standalone or as vet tool:
+//
+//	go vet -vettool=$(which cfg2env-lint) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/hasansino/cfg2env/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/hasansino/cfg2env"
+)
+
+// load finds struct type in package matching pattern and describes it
+func load(pattern string, typeName string) (*cfg2env.Struct, error) {
+	// dependencies are type-checked from source as well, so loading
+	// does not depend on export data format of installed toolchain
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedImports | packages.NeedDeps,
+	}, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package: %v", err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("pattern %q matched %d packages, expected one", pattern, len(pkgs))
+	}
+
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("failed to load package %s: %v", pkg.PkgPath, pkg.Errors[0])
+	}
+
+	obj, ok := pkg.Types.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in package %s", typeName, pkg.PkgPath)
+	}
+
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a struct", typeName)
+	}
+
+	docs := make(docs)
+	packages.Visit(pkgs, nil, func(p *packages.Package) {
+		for _, f := range p.Syntax {
+			docs.collect(f)
+		}
+	})
+
+	s := docs.describe(st)
+	s.Doc = docs[obj.Pos()]
+
+	return s, nil
+}
+
+// describe converts struct type to static description
+func (d docs) describe(st *types.Struct) *cfg2env.Struct {
+	s := &cfg2env.Struct{
+		Fields: make([]cfg2env.Field, 0, st.NumFields()),
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		var (
+			field = st.Field(i)
+			item  = cfg2env.Field{
+				Name: field.Name(),
+				Type: types.TypeString(field.Type(), qualifier),
+				Kind: underlyingType(field.Type()),
+				Tag:  st.Tag(i),
+				Doc:  d[field.Pos()],
+			}
+		)
+
+		if !field.Exported() {
+			continue
+		}
+
+		if nested, ok := field.Type().Underlying().(*types.Struct); ok {
+			item.Struct = d.describe(nested)
+			// group is documented by struct type, inline structs by field
+			item.Struct.Doc = item.Doc
+			if named, ok := field.Type().(*types.Named); ok && len(d[named.Obj().Pos()]) > 0 {
+				item.Struct.Doc = d[named.Obj().Pos()]
+			}
+		}
+
+		s.Fields = append(s.Fields, item)
+	}
+
+	return s
+}
+
+// underlyingType describes type using builtin types only,
+// same way cfg2env does it for reflected types
+func underlyingType(t types.Type) string {
+	if named, ok := t.(*types.Named); ok {
+		obj := named.Obj()
+		if obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Duration" {
+			return "time.Duration"
+		}
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return "[]" + underlyingType(u.Elem())
+	case *types.Array:
+		return fmt.Sprintf("[%d]%s", u.Len(), underlyingType(u.Elem()))
+	case *types.Map:
+		return "map[" + underlyingType(u.Key()) + "]" + underlyingType(u.Elem())
+	case *types.Pointer:
+		return "*" + underlyingType(u.Elem())
+	case *types.Basic:
+		return u.Name()
+	case *types.Struct:
+		return "struct"
+	case *types.Interface:
+		return "interface"
+	case *types.Signature:
+		return "func"
+	case *types.Chan:
+		return "chan"
+	default:
+		return types.TypeString(t, qualifier)
+	}
+}
+
+// qualifier renders package names same way reflection does
+func qualifier(p *types.Package) string {
+	return p.Name()
+}
+
+// docs maps positions of field and type names to their doc comments
+type docs map[token.Pos]string
+
+// collect gathers doc comments of type declarations and struct fields,
+// trailing line comment is used for fields without doc comment
+func (d docs) collect(f *ast.File) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.GenDecl:
+			for _, spec := range n.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				switch {
+				case ts.Doc != nil:
+					d[ts.Name.Pos()] = ts.Doc.Text()
+				case n.Doc != nil && len(n.Specs) == 1:
+					d[ts.Name.Pos()] = n.Doc.Text()
+				}
+			}
+		case *ast.Field:
+			text := n.Doc.Text()
+			if len(text) == 0 {
+				text = n.Comment.Text()
+			}
+			if len(text) == 0 {
+				break
+			}
+			for _, name := range n.Names {
+				d[name.Pos()] = text
+			}
+			if len(n.Names) == 0 {
+				d[embeddedPos(n.Type)] = text
+			}
+		}
+		return true
+	})
+}
+
+// embeddedPos returns position of type name of embedded field
+func embeddedPos(expr ast.Expr) token.Pos {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedPos(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Pos()
+	case *ast.IndexExpr:
+		return embeddedPos(t.X)
+	case *ast.IndexListExpr:
+		return embeddedPos(t.X)
+	default:
+		return expr.Pos()
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hasansino/cfg2env"
+)
+
+func TestLoad(t *testing.T) {
+	s, err := load("./testdata/config", "Config")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := cfg2env.New(cfg2env.WithExtraTagExtraction("validate")).Export(s)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# Default configuration\n\n# A (string) Just a dummy value for purpose of this test\nA=def_value_of_a\n# B (string)\n# Tag: validate -> oneof=one two three\nB=def_value_of_b\n\n## Nested\n\n# Foo ([]int64)\nNESTED_FOO=1,2,3\n# Bar (time.Duration)\nNESTED_BAR=10s\n# Baz (config.Level)\nNESTED_BAZ=info\n"
+	assert.Equal(t, expected, string(d))
+
+	nested := s.Fields[len(s.Fields)-1].Struct
+	assert.Equal(t, "[]int64", nested.Fields[0].Kind)
+	assert.Equal(t, "time.Duration", nested.Fields[1].Kind)
+	assert.Equal(t, "string", nested.Fields[2].Kind)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := load("./testdata/config", "Missing")
+	assert.Error(t, err)
+
+	_, err = load("./testdata/config", "Level")
+	assert.Error(t, err)
+}
+
+func TestLoad_Docs(t *testing.T) {
+	s, err := load("./testdata/config", "Documented")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Documented is configuration documented with comments\n", s.Doc)
+
+	d, err := cfg2env.New(cfg2env.WithHeaderText("")).Export(s)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Host (string) Host is server host,
+# it is documented with multi-line comment.
+HOST=localhost
+# Port (int) Port is server port
+PORT=80
+# Tagged (string) Tagged is described by tag
+TAGGED=
+
+## Database
+# Database is database connection configuration
+
+# DSN (string) DSN is connection string
+DATABASE_DSN=
+
+## Cache
+# Cache is inline struct documented by field
+
+# TTL (int)
+CACHE_TTL=60
+`
+	assert.Equal(t, expected, string(d))
+}
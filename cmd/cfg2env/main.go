@@ -0,0 +1,163 @@
+// Command cfg2env generates .env file from configuration struct
+// by reading source code of the package, without importing it.
+//
+// Usage:
+//
+//	cfg2env -type Config [flags] [package]
+//
+// Package defaults to current directory, so it can be used with go:generate:
+//
+//	//go:generate go run github.com/hasansino/cfg2env/cmd/cfg2env -type Config
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hasansino/cfg2env"
+)
+
+// formats maps format names to exporter methods
+var formats = map[string]func(e *cfg2env.Exporter, cfg interface{}) ([]byte, error){
+	"dotenv":         (*cfg2env.Exporter).Export,
+	"kubernetes":     (*cfg2env.Exporter).ExportKubernetes,
+	"kubernetes-env": (*cfg2env.Exporter).ExportKubernetesEnv,
+	"compose":        (*cfg2env.Exporter).ExportCompose,
+	"helm-values":    (*cfg2env.Exporter).ExportHelmValues,
+	"helm-template":  (*cfg2env.Exporter).ExportHelmTemplate,
+	"systemd":        (*cfg2env.Exporter).ExportSystemd,
+	"systemd-dropin": (*cfg2env.Exporter).ExportSystemdDropIn,
+	"posix": func(e *cfg2env.Exporter, cfg interface{}) ([]byte, error) {
+		return e.ExportShell(cfg, cfg2env.ShellPOSIX)
+	},
+	"fish": func(e *cfg2env.Exporter, cfg interface{}) ([]byte, error) {
+		return e.ExportShell(cfg, cfg2env.ShellFish)
+	},
+	"powershell": func(e *cfg2env.Exporter, cfg interface{}) ([]byte, error) {
+		return e.ExportShell(cfg, cfg2env.ShellPowerShell)
+	},
+	"manifest-json": func(e *cfg2env.Exporter, cfg interface{}) ([]byte, error) {
+		return e.ExportManifest(cfg, cfg2env.ManifestJSON)
+	},
+	"manifest-yaml": func(e *cfg2env.Exporter, cfg interface{}) ([]byte, error) {
+		return e.ExportManifest(cfg, cfg2env.ManifestYAML)
+	},
+}
+
+func main() {
+	var (
+		typeName   = flag.String("type", "", "name of configuration struct type (required)")
+		output     = flag.String("output", ".env", "output file path, - for stdout")
+		format     = flag.String("format", "dotenv", "output format: "+strings.Join(formatNames(), ", "))
+		header     = flag.String("header", "# Default configuration", "header text, empty to disable")
+		exclude    = flag.String("exclude", "", "comma-separated list of excluded fields")
+		include    = flag.String("include-paths", "", "comma-separated list of included field path globs")
+		excludeP   = flag.String("exclude-paths", "", "comma-separated list of excluded field path globs")
+		extraTags  = flag.String("extra-tags", "", "comma-separated list of tags included in description")
+		envTag     = flag.String("env-tag", "env", "environment variable name tag")
+		defaultTag = flag.String("default-tag", "default", "default value tag")
+		descTag    = flag.String("desc-tag", "desc", "description tag")
+		strict     = flag.Bool("strict", false, "fail on malformed struct tags")
+		tmpl       = flag.String("template", "", "path to text/template file used for dotenv format")
+		split      = flag.Bool("split", false, "split dotenv output into files by top-level groups and file tags")
+	)
+
+	flag.Usage = func() {
+		_, _ = fmt.Fprintf(flag.CommandLine.Output(), "Usage: cfg2env -type Config [flags] [package]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("cfg2env: ")
+
+	if len(*typeName) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	render, ok := formats[*format]
+	if !ok {
+		log.Fatalf("unknown format %q", *format)
+	}
+
+	pattern := "."
+	if flag.NArg() > 0 {
+		pattern = flag.Arg(0)
+	}
+
+	s, err := load(pattern, *typeName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := []cfg2env.Option{
+		cfg2env.WithEnvironmentTagName(*envTag),
+		cfg2env.WithDefaultValueTagName(*defaultTag),
+		cfg2env.WithDescriptionTagName(*descTag),
+		cfg2env.WithHeaderText(*header),
+		cfg2env.WithStrictTags(*strict),
+		cfg2env.WithExportedFileName(*output),
+		cfg2env.WithSplitByGroup(*split),
+	}
+	if len(*tmpl) > 0 {
+		t, err := os.ReadFile(*tmpl)
+		if err != nil {
+			log.Fatalf("failed to read template: %v", err)
+		}
+		opts = append(opts, cfg2env.WithTemplate(string(t)))
+	}
+	if len(*exclude) > 0 {
+		opts = append(opts, cfg2env.WithExcludedFields(strings.Split(*exclude, ",")...))
+	}
+	if len(*include) > 0 {
+		opts = append(opts, cfg2env.WithIncludedPaths(strings.Split(*include, ",")...))
+	}
+	if len(*excludeP) > 0 {
+		opts = append(opts, cfg2env.WithExcludedPaths(strings.Split(*excludeP, ",")...))
+	}
+	if len(*extraTags) > 0 {
+		for _, tag := range strings.Split(*extraTags, ",") {
+			opts = append(opts, cfg2env.WithExtraTagExtraction(tag))
+		}
+	}
+
+	exporter := cfg2env.New(opts...)
+
+	if *split {
+		if *format != "dotenv" || *output == "-" {
+			log.Fatal("-split requires dotenv format and output file")
+		}
+		if err := exporter.ToFile(s); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	data, err := render(exporter, s)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *output == "-" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*output, data, 0o644)
+	}
+	if err != nil {
+		log.Fatalf("failed to write output: %v", err)
+	}
+}
+
+func formatNames() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
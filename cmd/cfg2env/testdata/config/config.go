@@ -0,0 +1,45 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+type Config struct {
+	sync.RWMutex
+
+	A string `env:"A" default:"def_value_of_a"
+        desc:"Just a dummy value for purpose of this test"`
+	B        string `env:"B" default:"def_value_of_b" validate:"oneof=one two three"`
+	internal string
+	Nested   struct {
+		Foo []int64       `env:"NESTED_FOO" default:"1,2,3"`
+		Bar time.Duration `env:"NESTED_BAR" default:"10s"`
+		Baz Level         `env:"NESTED_BAZ" default:"info"`
+	}
+}
+
+type Level string
+
+//go:generate go run github.com/hasansino/cfg2env/cmd/cfg2env -type Documented -output .env.documented
+
+// Documented is configuration documented with comments
+type Documented struct {
+	// Host is server host,
+	// it is documented with multi-line comment.
+	Host string `env:"HOST" default:"localhost"`
+	Port int    `env:"PORT" default:"80"` // Port is server port
+	// Tagged comment is ignored in favor of desc tag
+	Tagged   string `env:"TAGGED" desc:"Tagged is described by tag"`
+	Database Database
+	// Cache is inline struct documented by field
+	Cache struct {
+		TTL int `env:"CACHE_TTL" default:"60"`
+	}
+}
+
+// Database is database connection configuration
+type Database struct {
+	// DSN is connection string
+	DSN string `env:"DATABASE_DSN"`
+}
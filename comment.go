@@ -0,0 +1,134 @@
+package cfg2env
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// commentLine splits comment line into prefix, indentation, list bullet and text
+var commentLine = regexp.MustCompile(`^(#+ ?)([ \t]*)((?:[-*+]|\d+[.)])[ \t]+)?(.*)$`)
+
+// inline markdown stripped by stripMarkdown, underscore emphasis is
+// not stripped since it is common in variable names
+var (
+	markdownLink   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	markdownStrong = regexp.MustCompile(`\*\*([^*\n]+)\*\*`)
+	markdownEm     = regexp.MustCompile(`\*([^*\s][^*\n]*)\*`)
+	markdownCode   = regexp.MustCompile("`([^`\n]+)`")
+)
+
+// comment formats text as comment according to exporter options,
+// group headers are prefixed with additional # char
+func (e *Exporter) comment(s string, group bool) string {
+	if e.commentMarkdown {
+		s = stripMarkdown(s)
+	}
+
+	switch {
+	case e.commentFormatter != nil:
+		s = "# " + strings.ReplaceAll(e.commentFormatter(s), "\n", "\n# ")
+	case e.commentIndent:
+		s = "# " + strings.Join(dedent(s), "\n# ")
+	default:
+		s = formatComment(s)
+	}
+
+	if group {
+		s = "#" + s
+	}
+
+	if e.commentWidth <= 0 {
+		return s
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(s, "\n") {
+		lines = append(lines, wrapComment(line, e.commentWidth)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stripMarkdown removes inline markdown, keeping lists and indentation
+func stripMarkdown(s string) string {
+	s = markdownCode.ReplaceAllString(s, "$1")
+	s = markdownLink.ReplaceAllString(s, "$1 ($2)")
+	s = markdownStrong.ReplaceAllString(s, "$1")
+	return markdownEm.ReplaceAllString(s, "$1")
+}
+
+// dedent removes common indentation of continuation lines, which
+// comes from source code formatting of multi-line tags, preserving
+// relative indentation of lists and code examples
+func dedent(s string) []string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t\r")
+	}
+
+	var (
+		common string
+		found  bool
+	)
+	for _, line := range lines[1:] {
+		if len(line) == 0 {
+			continue
+		}
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if !found {
+			common, found = indent, true
+			continue
+		}
+		for !strings.HasPrefix(indent, common) {
+			common = common[:len(common)-1]
+		}
+	}
+
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimPrefix(lines[i], common)
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		lines[i] = strings.ReplaceAll(indent, "\t", "    ") + line[len(indent):]
+	}
+
+	return lines
+}
+
+// wrapComment splits comment line longer than width at word boundaries,
+// continuation lines keep indentation and are aligned with list item text,
+// continuation of group header is prefixed with single # char, so it is
+// not rendered as another group header
+func wrapComment(line string, width int) []string {
+	if utf8.RuneCountInString(line) <= width {
+		return []string{line}
+	}
+
+	m := commentLine.FindStringSubmatch(line)
+	if m == nil {
+		return []string{line}
+	}
+
+	var (
+		prefix       = m[1]
+		indent       = m[2]
+		bullet       = m[3]
+		first        = prefix + indent + bullet
+		continuation = "# " + indent + strings.Repeat(" ", len(bullet))
+		lines        = make([]string, 0)
+		current      = first
+		empty        = true
+	)
+
+	for _, word := range strings.Fields(m[4]) {
+		if !empty && utf8.RuneCountInString(current)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, current)
+			current, empty = continuation, true
+		}
+		if !empty {
+			current += " "
+		}
+		current += word
+		empty = false
+	}
+
+	return append(lines, current)
+}
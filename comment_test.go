@@ -0,0 +1,137 @@
+package cfg2env
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigComment struct {
+	A string `env:"A" default:"a"
+		desc:"Supported modes:
+		  - fast, which skips validation of incoming requests and should only be used in trusted environments
+		  - safe
+		Example:
+		    mode=fast"`
+}
+
+func TestComment_Default(t *testing.T) {
+	d, err := New(WithHeaderText("")).Export(new(testConfigComment))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# A (string) Supported modes:\n" +
+		"# - fast, which skips validation of incoming requests and should only be used in trusted environments\n" +
+		"# - safe\n" +
+		"# Example:\n" +
+		"# mode=fast\n" +
+		"A=a\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestComment_PreserveIndent(t *testing.T) {
+	d, err := New(
+		WithHeaderText(""),
+		WithCommentPreserveIndent(true),
+	).Export(new(testConfigComment))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# A (string) Supported modes:\n" +
+		"#   - fast, which skips validation of incoming requests and should only be used in trusted environments\n" +
+		"#   - safe\n" +
+		"# Example:\n" +
+		"#     mode=fast\n" +
+		"A=a\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestComment_Width(t *testing.T) {
+	d, err := New(
+		WithHeaderText(""),
+		WithCommentPreserveIndent(true),
+		WithCommentWidth(40),
+	).Export(new(testConfigComment))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# A (string) Supported modes:\n" +
+		"#   - fast, which skips validation of\n" +
+		"#     incoming requests and should only\n" +
+		"#     be used in trusted environments\n" +
+		"#   - safe\n" +
+		"# Example:\n" +
+		"#     mode=fast\n" +
+		"A=a\n"
+	assert.Equal(t, expected, string(d))
+
+	for _, line := range strings.Split(string(d), "\n") {
+		assert.LessOrEqual(t, len(line), 40)
+	}
+}
+
+func TestComment_Formatter(t *testing.T) {
+	d, err := New(
+		WithHeaderText(""),
+		WithCommentFormatter(strings.ToUpper),
+	).Export(new(nestedStruct))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# OUTER (STRING)\nOUTER=outer\n\n## INNER\n\n# X (STRING)\nX=x\n# Y (INT)\nY=7\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestComment_GroupWidth(t *testing.T) {
+	type config struct {
+		Nested struct {
+			A string `env:"A"`
+		} `group:"Very long title of nested group"`
+	}
+
+	d, err := New(WithHeaderText(""), WithCommentWidth(20)).Export(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "## Very long title\n# of nested group\n\n# A (string)\nA=\n", string(d))
+}
+
+func TestComment_StripMarkdown(t *testing.T) {
+	type config struct {
+		A string `env:"A" desc:"**Required**, see [docs](https://example.com) and *use* it
+		  * keeps SOME_VAR_NAME"`
+	}
+
+	d, err := New(
+		WithHeaderText(""),
+		WithCommentPreserveIndent(true),
+		WithCommentStripMarkdown(true),
+	).Export(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# A (string) Required, see docs (https://example.com) and use it\n" +
+		"# * keeps SOME_VAR_NAME\n" +
+		"A=\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestStripMarkdown(t *testing.T) {
+	assert.Equal(t, "set mode=fast", stripMarkdown("set `mode=fast`"))
+	assert.Equal(t, "a * b * c", stripMarkdown("a * b * c"))
+	assert.Equal(t, "- item\n* item", stripMarkdown("- item\n* item"))
+}
+
+func TestWrapComment(t *testing.T) {
+	assert.Equal(t, []string{"# short"}, wrapComment("# short", 10))
+	assert.Equal(t, []string{"## Group", "# header"}, wrapComment("## Group header", 10))
+	assert.Equal(t, []string{"# 1. one", "#    two"}, wrapComment("# 1. one two", 10))
+	assert.Equal(t, []string{"# unbreakable-word"}, wrapComment("# unbreakable-word", 10))
+}
@@ -0,0 +1,62 @@
+package cfg2env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExportCompose exports struct as docker compose `environment` section,
+// values use `${VAR:-default}` interpolation, so variables can be
+// overridden from shell or .env file, secret fields are rendered
+// without default and required fields without default fail interpolation
+func (e *Exporter) ExportCompose(cfg interface{}) ([]byte, error) {
+	vars, err := e.variables(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	environment := e.extraEntryLines(func(name string, value string) []string {
+		return []string{e.composeEntry(name, composeDefault(name, value))}
+	})
+	environment = append(environment, e.variableLines(vars, func(v variable) []string {
+		var value string
+		switch {
+		case v.secret:
+			value = fmt.Sprintf("${%s}", v.envVarName)
+		case v.required && len(v.defValue) == 0:
+			value = fmt.Sprintf("${%s:?%s is required}", v.envVarName, v.envVarName)
+		default:
+			value = composeDefault(v.envVarName, v.defValue)
+		}
+		return []string{e.composeEntry(v.envVarName, value)}
+	})...)
+
+	lines := e.headerLines()
+	if e.composeListSyntax && len(environment) == 0 {
+		lines = append(lines, "environment: []")
+	} else {
+		lines = append(lines, yamlBlock("environment", environment)...)
+	}
+
+	return writeLines(lines)
+}
+
+// composeEntry renders single entry of environment section
+func (e *Exporter) composeEntry(name string, value string) string {
+	if e.composeListSyntax {
+		return "- " + yamlString(name+"="+value)
+	}
+	return name + ": " + yamlString(value)
+}
+
+// composeDefault renders interpolation with default value,
+// dollar signs are escaped to prevent further interpolation,
+// values with closing brace can not be used as interpolation
+// default, so they are rendered as literal values
+func composeDefault(name string, value string) string {
+	value = strings.ReplaceAll(value, "$", "$$")
+	if strings.Contains(value, "}") {
+		return value
+	}
+	return fmt.Sprintf("${%s:-%s}", name, value)
+}
@@ -0,0 +1,70 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigCompose struct {
+	Host     string `env:"HOST" default:"localhost" desc:"Server host"`
+	Password string `env:"PASSWORD" default:"qwerty" secret:"true"`
+	APIKey   string `env:"API_KEY" required:"true"`
+	Nested   struct {
+		Price string `env:"PRICE" default:"$100"`
+		Brace string `env:"BRACE" default:"${a}b"`
+	}
+}
+
+func TestExportCompose(t *testing.T) {
+	e := New(
+		WithHeaderText("# Test Header"),
+		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
+	)
+
+	d, err := e.ExportCompose(new(testConfigCompose))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Test Header
+
+environment:
+  # Extra pre-declared entries
+  COMPOSE_PROJECT_NAME: "${COMPOSE_PROJECT_NAME:-cfg2env}"
+  # Host (string) Server host
+  HOST: "${HOST:-localhost}"
+  # Password (string)
+  PASSWORD: "${PASSWORD}"
+  # APIKey (string)
+  API_KEY: "${API_KEY:?API_KEY is required}"
+  ## Nested
+  # Price (string)
+  PRICE: "${PRICE:-$$100}"
+  # Brace (string)
+  BRACE: "$${a}b"
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportCompose_ListSyntax(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithComposeListSyntax(true),
+	)
+
+	d, err := e.ExportCompose(new(simpleStruct))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "environment:\n  # A (string)\n  - \"A=${A:-a}\"\n  # B (int)\n  - \"B=${B:-42}\"\n"
+	assert.Equal(t, expected, string(d))
+
+	d, err = e.ExportCompose(&struct{}{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "environment: []\n", string(d))
+}
@@ -0,0 +1,79 @@
+package cfg2env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseCondition splits condition of dependsOn tag into name of controlling
+// variable and expected value, bare name expects variable to be true
+func parseCondition(s string) (name, value string) {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return strings.TrimSpace(s), ""
+	}
+	return strings.TrimSpace(name), strings.TrimSpace(value)
+}
+
+// conditionNote returns comment describing condition of variable or group
+func conditionNote(s string) string {
+	name, value := parseCondition(s)
+	if len(value) == 0 {
+		value = "true"
+	}
+	return fmt.Sprintf("Used only when %s=%s", name, value)
+}
+
+// conditionMet reports whether condition is met by default values
+func conditionMet(s string, defaults map[string]string) bool {
+	name, value := parseCondition(s)
+	if len(value) == 0 {
+		return isTrue(defaults[name])
+	}
+	return defaults[name] == value
+}
+
+// conditions applies conditions of nested groups to their variables,
+// drops variables of features which are not selected (see WithFeatures)
+// and marks variables which conditions are not met by default values
+func (e *Exporter) conditions(items []cfgItem) []cfgItem {
+	var (
+		paths    = make(map[string]string) // conditions of fields and groups
+		defaults = make(map[string]string)
+	)
+	for i := range items {
+		if len(items[i].dependsOn) > 0 {
+			paths[items[i].fieldPath] = items[i].dependsOn
+		}
+		if len(items[i].envVarName) > 0 {
+			defaults[items[i].envVarName] = items[i].defValue
+		}
+	}
+
+	// condition of item (including comments of field) is its own one
+	// or condition of closest group
+	condition := func(item cfgItem) string {
+		for path := item.fieldPath; len(path) > 0; path = parentPath(path) {
+			if len(paths[path]) > 0 {
+				return paths[path]
+			}
+		}
+		return ""
+	}
+
+	result := make([]cfgItem, 0, len(items))
+	for i := range items {
+		item := items[i]
+		if c := condition(item); len(c) > 0 {
+			if name, _ := parseCondition(c); e.features != nil && !e.features[name] {
+				continue
+			}
+			if !item.nestedGroup {
+				item.dependsOn = c
+				item.disabled = e.commentOutDisabled && !conditionMet(c, defaults)
+			}
+		}
+		result = append(result, item)
+	}
+	return result
+}
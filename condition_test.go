@@ -0,0 +1,99 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigConditions struct {
+	Mode    string `env:"MODE" default:"single"`
+	Tracing struct {
+		Enabled  bool   `env:"TRACING_ENABLED" default:"false"`
+		Endpoint string `env:"TRACING_ENDPOINT" default:"localhost:4317" dependsOn:"TRACING_ENABLED"`
+	}
+	Metrics struct {
+		Enabled bool   `env:"METRICS_ENABLED" default:"true"`
+		Path    string `env:"METRICS_PATH" default:"/metrics" dependsOn:"METRICS_ENABLED"`
+	}
+	Cluster struct {
+		Peers string `env:"CLUSTER_PEERS"`
+	} `dependsOn:"MODE=cluster"`
+}
+
+func TestExportConditions(t *testing.T) {
+	data, err := New(WithHeaderText(""), WithCommentOutDisabled(true)).Export(new(testConfigConditions))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# Mode (string)
+MODE=single
+
+## Tracing
+
+# Enabled (bool)
+TRACING_ENABLED=false
+# Endpoint (string)
+# Used only when TRACING_ENABLED=true
+# TRACING_ENDPOINT=localhost:4317
+
+## Metrics
+
+# Enabled (bool)
+METRICS_ENABLED=true
+# Path (string)
+# Used only when METRICS_ENABLED=true
+METRICS_PATH=/metrics
+
+## Cluster
+# Used only when MODE=cluster
+
+# Peers (string)
+# CLUSTER_PEERS=
+`, string(data))
+}
+
+func TestManifestConditions(t *testing.T) {
+	m, err := New().Manifest(new(testConfigConditions))
+	if err != nil {
+		t.Error(err)
+	}
+
+	conditions := make(map[string]string)
+	for _, v := range m.Variables {
+		conditions[v.Name] = v.DependsOn
+	}
+	assert.Equal(t, map[string]string{
+		"MODE":             "",
+		"TRACING_ENABLED":  "",
+		"TRACING_ENDPOINT": "TRACING_ENABLED",
+		"METRICS_ENABLED":  "",
+		"METRICS_PATH":     "METRICS_ENABLED",
+		"CLUSTER_PEERS":    "MODE=cluster",
+	}, conditions)
+}
+
+func TestExportFeatures(t *testing.T) {
+	data, err := New(WithHeaderText(""), WithFeatures("METRICS_ENABLED")).Export(new(testConfigConditions))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# Mode (string)
+MODE=single
+
+## Tracing
+
+# Enabled (bool)
+TRACING_ENABLED=false
+
+## Metrics
+
+# Enabled (bool)
+METRICS_ENABLED=true
+# Path (string)
+# Used only when METRICS_ENABLED=true
+METRICS_PATH=/metrics
+`, string(data))
+}
@@ -0,0 +1,123 @@
+package cfg2env
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// aliases returns former names of variable listed in aliases tag, comma-separated
+func (e *Exporter) aliases(tag MultilineStructTag) []string {
+	var aliases []string
+	for _, alias := range strings.Split(tag.Get(e.aliasesTagName), ",") {
+		if alias = strings.TrimSpace(alias); len(alias) > 0 {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// deprecationWarnings returns warning comments of deprecated or renamed variable
+func deprecationWarnings(name, deprecated string, aliases []string) []string {
+	warnings := make([]string, 0, len(aliases)+1)
+	if len(deprecated) > 0 {
+		warnings = append(warnings, fmt.Sprintf("WARNING: %s is deprecated: %s", name, deprecated))
+	}
+	for _, alias := range aliases {
+		warnings = append(warnings, fmt.Sprintf("WARNING: %s is renamed to %s", alias, name))
+	}
+	return warnings
+}
+
+// Migration is single change made to .env file by Migrate
+type Migration struct {
+	Line    int    // line number in original file, starting with 1
+	Old     string // former variable name found in file
+	New     string // current variable name
+	Dropped bool   // old entry is removed since new variable is already set
+}
+
+func (m Migration) String() string {
+	if m.Dropped {
+		return fmt.Sprintf("line %d: %s removed, %s is already set", m.Line, m.Old, m.New)
+	}
+	return fmt.Sprintf("line %d: %s renamed to %s", m.Line, m.Old, m.New)
+}
+
+// envLine matches variable definition, optionally prefixed with export
+var envLine = regexp.MustCompile(`^(\s*(?:export\s+)?)([A-Za-z_][A-Za-z0-9_]*)(\s*=.*)$`)
+
+// Migrate rewrites content of .env file, moving values of former variable names
+// (see aliases tag) to current names, comments and unknown variables are kept as is,
+// if current variable is already set, entry of former name is removed
+func (e *Exporter) Migrate(cfg interface{}, data []byte) ([]byte, []Migration, error) {
+	// hidden variables are renamed as well
+	items, err := e.walk(cfg, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	renamed := make(map[string]string)
+	for i := range items {
+		for _, alias := range items[i].aliases {
+			renamed[alias] = items[i].envVarName
+		}
+	}
+
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read data: %v", err)
+	}
+
+	defined := make(map[string]bool)
+	for _, line := range lines {
+		if m := envLine.FindStringSubmatch(line); m != nil {
+			defined[m[2]] = true
+		}
+	}
+
+	var (
+		buff       = new(bytes.Buffer)
+		migrations = make([]Migration, 0)
+	)
+	for i, line := range lines {
+		if m := envLine.FindStringSubmatch(line); m != nil {
+			if name, ok := renamed[m[2]]; ok {
+				migration := Migration{Line: i + 1, Old: m[2], New: name, Dropped: defined[name]}
+				migrations = append(migrations, migration)
+				if migration.Dropped {
+					continue
+				}
+				defined[name] = true
+				line = m[1] + name + m[3]
+			}
+		}
+		buff.WriteString(line + "\n")
+	}
+
+	return buff.Bytes(), migrations, nil
+}
+
+// MigrateFile rewrites .env file in place, see Migrate,
+// file is not touched if there is nothing to migrate
+func (e *Exporter) MigrateFile(cfg interface{}, name string) ([]Migration, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	migrated, migrations, err := e.Migrate(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return migrations, nil
+	}
+	return migrations, writeFile(name, migrated)
+}
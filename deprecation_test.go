@@ -0,0 +1,81 @@
+package cfg2env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigDeprecation struct {
+	Host    string `env:"DB_HOST" default:"localhost" aliases:"OLD_DB_HOST, DATABASE_HOST"`
+	Port    int    `env:"DB_PORT" default:"5432" aliases:"OLD_DB_PORT"`
+	Legacy  bool   `env:"LEGACY_MODE" deprecated:"will be removed in v2"`
+	Timeout string `env:"TIMEOUT" default:"5s"`
+}
+
+func TestExportDeprecation(t *testing.T) {
+	data, err := New(WithHeaderText("")).Export(new(testConfigDeprecation))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# Host (string)
+# WARNING: OLD_DB_HOST is renamed to DB_HOST
+# WARNING: DATABASE_HOST is renamed to DB_HOST
+DB_HOST=localhost
+# Port (int)
+# WARNING: OLD_DB_PORT is renamed to DB_PORT
+DB_PORT=5432
+# Legacy (bool)
+# WARNING: LEGACY_MODE is deprecated: will be removed in v2
+LEGACY_MODE=
+# Timeout (string)
+TIMEOUT=5s
+`, string(data))
+}
+
+func TestMigrate(t *testing.T) {
+	input := `# database
+export OLD_DB_HOST=db.local
+OLD_DB_PORT = 6543
+DB_PORT=5432
+DATABASE_HOST=ignored
+# OLD_DB_HOST=commented
+UNKNOWN=value
+`
+
+	data, migrations, err := New().Migrate(new(testConfigDeprecation), []byte(input))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# database
+export DB_HOST=db.local
+DB_PORT=5432
+# OLD_DB_HOST=commented
+UNKNOWN=value
+`, string(data))
+
+	assert.Equal(t, []Migration{
+		{Line: 2, Old: "OLD_DB_HOST", New: "DB_HOST"},
+		{Line: 3, Old: "OLD_DB_PORT", New: "DB_PORT", Dropped: true},
+		{Line: 5, Old: "DATABASE_HOST", New: "DB_HOST", Dropped: true},
+	}, migrations)
+	assert.Equal(t, "line 2: OLD_DB_HOST renamed to DB_HOST", migrations[0].String())
+	assert.Equal(t, "line 3: OLD_DB_PORT removed, DB_PORT is already set", migrations[1].String())
+}
+
+func TestMigrateFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".env")
+	assert.NoError(t, os.WriteFile(name, []byte("OLD_DB_HOST=db.local\n"), 0o644))
+
+	migrations, err := New().MigrateFile(new(testConfigDeprecation), name)
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 1)
+
+	d, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	assert.Equal(t, "DB_HOST=db.local\n", string(d))
+}
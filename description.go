@@ -0,0 +1,146 @@
+package cfg2env
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DescriptionPart is a part of variable description comment
+type DescriptionPart int
+
+const (
+	// DescriptionFieldName is name of struct field, e.g. Foo
+	DescriptionFieldName DescriptionPart = iota
+	// DescriptionGoType is Go type of field, e.g. ([]int64)
+	DescriptionGoType
+	// DescriptionFriendlyType is human-readable type of field, e.g. (list of integers)
+	DescriptionFriendlyType
+	// DescriptionFieldPath is full path of struct field, e.g. Nested.NestedTwo.Foo
+	DescriptionFieldPath
+	// DescriptionText is description from tag or doc comment
+	DescriptionText
+	// DescriptionExtraTags is extracted extra tags, e.g. [validate: required],
+	// if it is not part of layout, extra tags are rendered on separate lines
+	DescriptionExtraTags
+)
+
+// description renders variable description according to layout
+func (e *Exporter) description(field fieldInfo) string {
+	parts := make([]string, 0, len(e.descriptionLayout))
+	for _, part := range e.descriptionLayout {
+		var s string
+		switch part {
+		case DescriptionFieldName:
+			s = field.name
+		case DescriptionGoType:
+			s = "(" + field.typeName + ")"
+		case DescriptionFriendlyType:
+			s = "(" + friendlyType(field.kind) + ")"
+		case DescriptionFieldPath:
+			s = field.path
+		case DescriptionText:
+			s = field.tag.Get(e.descriptionTagName)
+			if len(s) == 0 {
+				s = field.doc
+			}
+		case DescriptionExtraTags:
+			tags := make([]string, 0, len(e.extraTags))
+			for i := range e.extraTags {
+				if v := field.tag.Get(e.extraTags[i]); len(v) > 0 {
+					tags = append(tags, e.extraTags[i]+": "+v)
+				}
+			}
+			if len(tags) > 0 {
+				s = "[" + strings.Join(tags, ", ") + "]"
+			}
+		}
+		if len(s) > 0 {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// inlineExtraTags reports whether extra tags are part of description
+func (e *Exporter) inlineExtraTags() bool {
+	for _, part := range e.descriptionLayout {
+		if part == DescriptionExtraTags {
+			return true
+		}
+	}
+	return false
+}
+
+// underlyingType describes type using builtin types only, e.g. []string for []Level,
+// time.Duration is kept as is since it has its own textual representation
+func underlyingType(t reflect.Type) string {
+	if t.PkgPath() == "time" && t.Name() == "Duration" {
+		return "time.Duration"
+	}
+	switch t.Kind() {
+	case reflect.Slice:
+		return "[]" + underlyingType(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), underlyingType(t.Elem()))
+	case reflect.Map:
+		return "map[" + underlyingType(t.Key()) + "]" + underlyingType(t.Elem())
+	case reflect.Ptr:
+		return "*" + underlyingType(t.Elem())
+	default:
+		return t.Kind().String()
+	}
+}
+
+// friendlyType converts underlying type to human-readable form,
+// e.g. []int64 becomes list of integers
+func friendlyType(kind string) string {
+	switch {
+	case strings.HasPrefix(kind, "*"):
+		return friendlyType(kind[1:])
+	case strings.HasPrefix(kind, "["):
+		end := strings.Index(kind, "]")
+		return "list of " + plural(friendlyType(kind[end+1:]))
+	case strings.HasPrefix(kind, "map["):
+		depth, end := 0, 0
+		for end = 3; end < len(kind); end++ {
+			if kind[end] == '[' {
+				depth++
+			} else if kind[end] == ']' {
+				depth--
+			}
+			if depth == 0 {
+				break
+			}
+		}
+		if end >= len(kind) {
+			return kind
+		}
+		return "map of " + plural(friendlyType(kind[4:end])) + " to " + plural(friendlyType(kind[end+1:]))
+	case kind == "time.Duration":
+		return "duration"
+	case kind == "bool":
+		return "boolean"
+	case kind == "string":
+		return "string"
+	case kind == "int", kind == "int8", kind == "int16", kind == "int32", kind == "int64",
+		kind == "uint", kind == "uint8", kind == "uint16", kind == "uint32", kind == "uint64", kind == "uintptr":
+		return "integer"
+	case kind == "float32", kind == "float64":
+		return "number"
+	case kind == "complex64", kind == "complex128":
+		return "complex number"
+	default:
+		return kind
+	}
+}
+
+// plural returns plural form of friendly type name
+func plural(s string) string {
+	for _, prefix := range []string{"list of ", "map of "} {
+		if strings.HasPrefix(s, prefix) {
+			return strings.Replace(s, " of ", "s of ", 1)
+		}
+	}
+	return s + "s"
+}
@@ -0,0 +1,89 @@
+package cfg2env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLevel string
+
+type testConfigDescription struct {
+	Level  testLevel `env:"LEVEL" default:"info" desc:"Log level" validate:"oneof=debug info"`
+	Nested struct {
+		IDs     []int64                  `env:"IDS" default:"1,2"`
+		Timeout *time.Duration           `env:"TIMEOUT" default:"5s" validate:"required" example:"10s"`
+		Limits  map[string][]float64     `env:"LIMITS"`
+		Flags   map[testLevel]bool       `env:"FLAGS"`
+		Matrix  [][2]uint8               `env:"MATRIX"`
+		Any     interface{}              `env:"ANY"`
+		Named   map[string]time.Duration `env:"NAMED"`
+	}
+}
+
+func TestDescriptionLayout(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithExtraTagExtraction("validate"),
+		WithExtraTagExtraction("example"),
+		WithDescriptionLayout(DescriptionFieldPath, DescriptionFriendlyType, DescriptionExtraTags, DescriptionText),
+	)
+
+	d, err := e.Export(new(testConfigDescription))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Level (string) [validate: oneof=debug info] Log level
+LEVEL=info
+
+## Nested
+
+# Nested.IDs (list of integers)
+IDS=1,2
+# Nested.Timeout (duration) [validate: required, example: 10s]
+TIMEOUT=5s
+# Nested.Limits (map of strings to lists of numbers)
+LIMITS=
+# Nested.Flags (map of strings to booleans)
+FLAGS=
+# Nested.Matrix (list of lists of integers)
+MATRIX=
+# Nested.Any (interface)
+ANY=
+# Nested.Named (map of strings to durations)
+NAMED=
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestDescriptionLayout_ExtraTagsOnSeparateLines(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithExtraTagExtraction("validate"),
+		WithDescriptionLayout(DescriptionText, DescriptionGoType),
+	)
+
+	d, err := e.Export(new(tagStruct))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# descA (string)\n# Tag: validate -> oneof=foo bar\nA=a\n# (string)\nB=b\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestDescriptionLayout_Empty(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithDescriptionLayout(DescriptionText),
+	)
+
+	d, err := e.Export(new(tagStruct))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "# descA\nA=a\nB=b\n", string(d))
+}
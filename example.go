@@ -0,0 +1,36 @@
+package cfg2env
+
+// exampleValue returns value of variable in example file:
+// placeholder for secrets, example tag value if present
+// and placeholder for required variables without value
+func (e *Exporter) exampleValue(tag MultilineStructTag, value string) string {
+	if isTrue(tag.Get(e.secretTagName)) {
+		return e.secretPlaceholder
+	}
+	if v, ok := tag.Lookup(e.exampleTagName); ok {
+		return v
+	}
+	if isTrue(tag.Get(e.requiredTagName)) && len(value) == 0 {
+		return e.requiredPlaceholder
+	}
+	return value
+}
+
+// ExportExample exports struct in .env format, see Export, using values
+// of example tag instead of default values, secret variables and required
+// variables without value are replaced with placeholders
+func (e *Exporter) ExportExample(cfg interface{}) ([]byte, error) {
+	p := *e
+	p.example = true
+	return p.Export(cfg)
+}
+
+// toExampleFile exports example data to file named
+// after exported file with .example suffix, e.g. .env.example
+func (e *Exporter) toExampleFile(cfg interface{}) error {
+	data, err := e.ExportExample(cfg)
+	if err != nil {
+		return err
+	}
+	return writeFile(e.fileName+".example", data)
+}
@@ -0,0 +1,59 @@
+package cfg2env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigExample struct {
+	DatabaseURL string `env:"DATABASE_URL" example:"postgres://user@localhost:5432/db"`
+	Password    string `env:"PASSWORD" default:"secret" example:"qwerty" secret:"true"`
+	APIKey      string `env:"API_KEY" required:"true"`
+	Region      string `env:"REGION" default:"eu" required:"true"`
+	Port        int    `env:"PORT" default:"8080"`
+}
+
+func TestExportExample(t *testing.T) {
+	e := New(WithHeaderText(""))
+
+	data, err := e.ExportExample(new(testConfigExample))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# DatabaseURL (string)
+DATABASE_URL=postgres://user@localhost:5432/db
+# Password (string)
+PASSWORD=CHANGE_ME
+# APIKey (string)
+API_KEY=TODO
+# Region (string)
+REGION=eu
+# Port (int)
+PORT=8080
+`, string(data))
+}
+
+func TestToFileExample(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".env")
+	e := New(WithExportedFileName(name), WithExampleFile(true), WithExampleTagName("sample"))
+
+	type config struct {
+		URL string `env:"URL" example:"http://example.com" sample:"http://sample.com"`
+	}
+
+	if err := e.ToFile(new(config)); err != nil {
+		t.Error(err)
+	}
+
+	d, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), "URL=\n")
+
+	d, err = os.ReadFile(name + ".example")
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), "URL=http://sample.com\n")
+}
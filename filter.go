@@ -0,0 +1,96 @@
+package cfg2env
+
+import (
+	"path"
+	"strings"
+)
+
+// FieldFilter reports whether struct field matches filter,
+// it receives full field path (e.g. Nested.Foo), Go type name
+// (e.g. sync.Mutex) and field tag
+type FieldFilter func(fieldPath, typeName string, tag MultilineStructTag) bool
+
+// PathFilter returns filter matching fields by path glob patterns,
+// pattern elements are separated by dots and matched as in path.Match,
+// `**` matches any number of elements, e.g. `Nested.*` or `**.Internal`.
+// Field matches if its path or path of any parent struct matches.
+func PathFilter(patterns ...string) FieldFilter {
+	return func(fieldPath, _ string, _ MultilineStructTag) bool {
+		for p := fieldPath; len(p) > 0; p = parentPath(p) {
+			for _, pattern := range patterns {
+				if matchPath(strings.Split(pattern, "."), strings.Split(p, ".")) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// TypeFilter returns filter matching fields by Go type name, e.g. sync.Mutex
+func TypeFilter(typeNames ...string) FieldFilter {
+	return func(_, typeName string, _ MultilineStructTag) bool {
+		for _, t := range typeNames {
+			if t == typeName {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// matchPath matches path elements against pattern elements
+func matchPath(pattern, elems []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(elems); i++ {
+				if matchPath(pattern[1:], elems[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(elems) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], elems[0]); err != nil || !ok {
+			return false
+		}
+		pattern, elems = pattern[1:], elems[1:]
+	}
+	return len(elems) == 0
+}
+
+// isFiltered reports whether field is filtered out, exclude filters apply to all
+// fields, include filters apply to non-struct fields only, so nested structs
+// are walked and kept if any of their fields is included, see keepGroup
+func (e *Exporter) isFiltered(fieldPath, typeName string, tag MultilineStructTag, leaf bool) bool {
+	for _, f := range e.excludeFilters {
+		if f(fieldPath, typeName, tag) {
+			return true
+		}
+	}
+	if !leaf || len(e.includeFilters) == 0 {
+		return false
+	}
+	for _, f := range e.includeFilters {
+		if f(fieldPath, typeName, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// keepGroup reports whether nested group with given items is exported,
+// groups without variables are dropped when include filters are set
+func (e *Exporter) keepGroup(items []cfgItem) bool {
+	if len(e.includeFilters) == 0 {
+		return true
+	}
+	for i := range items {
+		if len(items[i].envVarName) > 0 {
+			return true
+		}
+	}
+	return false
+}
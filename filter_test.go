@@ -0,0 +1,135 @@
+package cfg2env
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigFilters struct {
+	Mu       sync.Mutex
+	Internal string `env:"INTERNAL" default:"i"`
+	Port     int    `env:"PORT" default:"80"`
+	Nested   struct {
+		Internal string        `env:"NESTED_INTERNAL" default:"ni"`
+		Timeout  time.Duration `env:"NESTED_TIMEOUT" default:"1s"`
+		Deep     struct {
+			Port int `env:"DEEP_PORT" default:"81" scope:"private"`
+		}
+	}
+	Other struct {
+		Name string `env:"OTHER_NAME" default:"o"`
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tests := []struct {
+		pattern, path string
+		match         bool
+	}{
+		{"Nested.*", "Nested.Foo", true},
+		{"Nested.*", "Nested", false},
+		{"Nested.*", "Nested.Deep.Port", true}, // parent Nested.Deep matches
+		{"Nested.**", "Nested.Deep.Port", true},
+		{"**.Internal", "Internal", true},
+		{"**.Internal", "Nested.Internal", true},
+		{"**.Internal", "Nested.Internals", false},
+		{"**.Port", "Nested.Deep.Port", true},
+		{"*.Deep.*", "Nested.Deep.Port", true},
+		{"Nest?d.Time*", "Nested.Timeout", true},
+		{"Nested.[", "Nested.Foo", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, PathFilter(tt.pattern)(tt.path, "", ""), tt.pattern+" "+tt.path)
+	}
+}
+
+func TestExportExcludedPaths(t *testing.T) {
+	data, err := New(
+		WithHeaderText(""),
+		WithExcludedTypes("sync.Mutex", "time.Duration"),
+		WithExcludedPaths("**.Internal", "Other"),
+	).Export(new(testConfigFilters))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# Port (int)
+PORT=80
+
+## Nested
+
+## Nested.Deep
+
+# Port (int)
+DEEP_PORT=81
+`, string(data))
+}
+
+func TestExportDefaultExcludedFields(t *testing.T) {
+	type config struct {
+		Lock    sync.RWMutex
+		RWMutex string `env:"RWMUTEX"`
+	}
+
+	data, err := New(WithHeaderText("")).Export(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.NotContains(t, string(data), "RWMUTEX")
+
+	data, err = New(
+		WithHeaderText(""),
+		WithDefaultExcludedFields(false),
+		WithExcludedTypes("sync.RWMutex"),
+	).Export(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "# RWMutex (string)\nRWMUTEX=\n", string(data))
+}
+
+func TestExportIncludedPaths(t *testing.T) {
+	data, err := New(
+		WithHeaderText(""),
+		WithIncludedPaths("**.Port", "Other"),
+		WithExcludeFilter(func(_, _ string, tag MultilineStructTag) bool {
+			return tag.Get("scope") == "private"
+		}),
+	).Export(new(testConfigFilters))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# Port (int)
+PORT=80
+
+## Other
+
+# Name (string)
+OTHER_NAME=o
+`, string(data))
+
+	data, err = New(WithHeaderText(""), WithIncludedTypes("time.Duration")).Export(new(testConfigFilters))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "## Nested\n\n# Timeout (time.Duration)\nNESTED_TIMEOUT=1s\n", string(data))
+}
+
+func TestDescribeIncludedPaths(t *testing.T) {
+	s := &Struct{Fields: []Field{
+		{Name: "A", Type: "string", Tag: `env:"A"`},
+		{Name: "Nested", Struct: &Struct{Fields: []Field{
+			{Name: "B", Type: "string", Tag: `env:"B"`},
+		}}},
+	}}
+
+	data, err := New(WithHeaderText(""), WithIncludedPaths("Nested.B")).Export(s)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "## Nested\n\n# B (string)\nB=\n", string(data))
+}
@@ -0,0 +1,130 @@
+package cfg2env
+
+import "strings"
+
+// GroupOrder determines order of scalar fields and nested groups within struct
+type GroupOrder int
+
+const (
+	// GroupOrderDeclaration keeps order of struct declaration
+	GroupOrderDeclaration GroupOrder = iota
+	// GroupOrderFieldsFirst emits scalar fields before nested groups
+	GroupOrderFieldsFirst
+	// GroupOrderGroupsFirst emits nested groups before scalar fields
+	GroupOrderGroupsFirst
+)
+
+// groupItem creates header of nested group, title and description
+// are taken from group and description tags of struct field,
+// doc is used if there is no description tag
+func (e *Exporter) groupItem(fieldPath string, tag MultilineStructTag, doc string) cfgItem {
+	comment := fieldPath
+	if title := tag.Get(e.groupTagName); len(title) > 0 {
+		comment = title
+	}
+	if desc := tag.Get(e.descriptionTagName); len(desc) > 0 {
+		comment += "\n " + desc
+	} else if len(doc) > 0 {
+		comment += "\n " + doc
+	}
+	dependsOn := tag.Get(e.dependsOnTagName)
+	if len(dependsOn) > 0 {
+		comment += "\n " + conditionNote(dependsOn)
+	}
+	return cfgItem{
+		nestedGroup: true,
+		comment:     comment,
+		fieldPath:   fieldPath,
+		dependsOn:   dependsOn,
+		hidden:      e.hasControl(tag, ControlHidden),
+		file:        tag.Get(e.fileTagName),
+	}
+}
+
+// level collects items of single struct level
+type level struct {
+	order  GroupOrder
+	all    []cfgItem
+	fields []cfgItem
+	groups []cfgItem
+}
+
+func (e *Exporter) newLevel() *level {
+	return &level{
+		order:  e.groupOrder,
+		all:    make([]cfgItem, 0),
+		fields: make([]cfgItem, 0),
+		groups: make([]cfgItem, 0),
+	}
+}
+
+// addField adds items of scalar field
+func (l *level) addField(items ...cfgItem) {
+	l.all = append(l.all, items...)
+	l.fields = append(l.fields, items...)
+}
+
+// addGroup adds nested group header followed by its items
+func (l *level) addGroup(header cfgItem, items ...cfgItem) {
+	l.all = append(append(l.all, header), items...)
+	l.groups = append(append(l.groups, header), items...)
+}
+
+// items returns collected items in configured order
+func (l *level) items() []cfgItem {
+	switch l.order {
+	case GroupOrderFieldsFirst:
+		return append(l.fields, l.groups...)
+	case GroupOrderGroupsFirst:
+		return append(l.groups, l.fields...)
+	default:
+		return l.all
+	}
+}
+
+// resumeGroups repeats header of parent group (or root group) before fields
+// which follow nested groups, so fields do not look like members of
+// preceding nested group, e.g. when nested struct is declared first,
+// other renderers do the same in variableLines
+func (e *Exporter) resumeGroups(items []cfgItem) []cfgItem {
+	var (
+		result  = make([]cfgItem, 0, len(items))
+		titles  = map[string]string{``: e.rootGroupTitle}
+		current string
+	)
+	for i := range items {
+		path := items[i].fieldPath
+		if items[i].nestedGroup {
+			titles[path] = strings.SplitN(items[i].comment, "\n", 2)[0]
+			current = path
+		} else if parent := parentPath(path); parent != current {
+			result = append(result, cfgItem{
+				nestedGroup: true,
+				resumed:     true,
+				comment:     titles[parent],
+				fieldPath:   parent,
+			})
+			current = parent
+		}
+		result = append(result, items[i])
+	}
+	return result
+}
+
+// omitEmptyGroups removes headers of groups without own variables
+func omitEmptyGroups(items []cfgItem) []cfgItem {
+	used := make(map[string]bool)
+	for i := range items {
+		if len(items[i].envVarName) > 0 {
+			used[parentPath(items[i].fieldPath)] = true
+		}
+	}
+	result := make([]cfgItem, 0, len(items))
+	for i := range items {
+		if items[i].nestedGroup && !used[items[i].fieldPath] {
+			continue
+		}
+		result = append(result, items[i])
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigGroups struct {
+	A        string `env:"A" default:"a"`
+	Database struct {
+		Host string `env:"DB_HOST" default:"localhost"`
+	} `group:"Database" desc:"Connection to primary database"`
+	B          string `env:"B" default:"b"`
+	DeepNested struct {
+		DeepNested2 struct {
+			Foo string `env:"DEEP_NESTED_FOO" default:"foo"`
+		}
+	}
+}
+
+func TestGroups_TitleAndOmitEmpty(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithOmitEmptyGroups(true),
+	)
+
+	d, err := e.Export(new(testConfigGroups))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# A (string)
+A=a
+
+## Database
+# Connection to primary database
+
+# Host (string)
+DB_HOST=localhost
+
+## Root
+
+# B (string)
+B=b
+
+## DeepNested.DeepNested2
+
+# Foo (string)
+DEEP_NESTED_FOO=foo
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestGroups_FieldsFirst(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithGroupOrder(GroupOrderFieldsFirst),
+	)
+
+	d, err := e.Export(new(testConfigNestedFirst))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# A (string)\nA=def_value_of_a\n# B (string)\nB=def_value_of_b\n# C (string)\nC=def_value_of_c\n\n" +
+		"## NestedFirst\n\n" +
+		"# A (string)\nNestedFirst_A=def_value_of_a\n# B (string)\nNestedFirst_B=def_value_of_b\n# C (string)\nNestedFirst_C=def_value_of_c\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestGroups_GroupsFirst(t *testing.T) {
+	e := New(WithGroupOrder(GroupOrderGroupsFirst))
+
+	items := e.reflectCfg(new(testConfigGroups), "")
+
+	var order []string
+	for _, item := range items {
+		if len(item.envVarName) > 0 {
+			order = append(order, item.envVarName)
+		}
+	}
+	assert.Equal(t, []string{"DB_HOST", "DEEP_NESTED_FOO", "A", "B"}, order)
+}
+
+func TestGroups_Resume(t *testing.T) {
+	type config struct {
+		Nested struct {
+			Foo       string `env:"NESTED_FOO"`
+			NestedTwo struct {
+				Foo string `env:"NESTED_NESTED2_FOO"`
+			} `group:"Second level" desc:"Description is not repeated"`
+			Bar string `env:"NESTED_BAR"`
+		} `group:"First level" desc:"Description is not repeated"`
+		A string `env:"A" default:"a"`
+	}
+
+	e := New(
+		WithHeaderText(""),
+		WithRootGroupTitle("General"),
+	)
+
+	d, err := e.Export(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `## First level
+# Description is not repeated
+
+# Foo (string)
+NESTED_FOO=
+
+## Second level
+# Description is not repeated
+
+# Foo (string)
+NESTED_NESTED2_FOO=
+
+## First level
+
+# Bar (string)
+NESTED_BAR=
+
+## General
+
+# A (string)
+A=a
+`
+	assert.Equal(t, expected, string(d))
+
+	// other renderers resume headers by title as well
+	d, err = e.ExportShell(new(config), ShellPOSIX)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected = `## First level
+# Description is not repeated
+# Foo (string)
+export NESTED_FOO=''
+## Second level
+# Description is not repeated
+# Foo (string)
+export NESTED_NESTED2_FOO=''
+## First level
+# Bar (string)
+export NESTED_BAR=''
+## General
+# A (string)
+export A='a'
+`
+	assert.Equal(t, expected, string(d))
+
+	d, err = e.ExportCompose(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Contains(t, string(d), "  ## General\n  # A (string)\n  A: \"${A:-a}\"\n")
+}
+
+func TestGroups_SameTitle(t *testing.T) {
+	type config struct {
+		First struct {
+			Foo string `env:"FIRST_FOO"`
+		} `group:"Storage"`
+		Second struct {
+			Foo string `env:"SECOND_FOO"`
+		} `group:"Storage"`
+	}
+
+	d, err := New(WithHeaderText("")).ExportShell(new(config), ShellPOSIX)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `## Storage
+# Foo (string)
+export FIRST_FOO=''
+## Storage
+# Foo (string)
+export SECOND_FOO=''
+`
+	assert.Equal(t, expected, string(d))
+}
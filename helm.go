@@ -0,0 +1,117 @@
+package cfg2env
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ExportHelmValues exports struct as helm values.yaml,
+// nested groups are mirrored as nested mappings with camelCased keys
+func (e *Exporter) ExportHelmValues(cfg interface{}) ([]byte, error) {
+	items, err := e.items(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		values   = make([]string, 0)
+		comments = make([]string, 0)
+	)
+
+	for i := range items {
+		indent := strings.Repeat("  ", strings.Count(items[i].fieldPath, "."))
+		switch {
+		case items[i].nestedGroup:
+			key := helmKey(items[i].fieldPath)
+			if hasDescendants(items, i) {
+				values = append(values, indent+key+":")
+			} else {
+				values = append(values, indent+key+": {}")
+			}
+		case len(items[i].comment) > 0:
+			comments = append(comments, strings.Split(e.comment(items[i].comment, false), "\n")...)
+		default:
+			values = append(values, indentLines(comments, indent)...)
+			values = append(values, indent+helmKey(items[i].fieldPath)+": "+yamlString(items[i].defValue))
+			comments = make([]string, 0)
+		}
+	}
+
+	lines := e.headerLines()
+	if len(e.helmValuesPrefix) > 0 {
+		lines = append(lines, yamlBlock(e.helmValuesPrefix, values)...)
+	} else {
+		lines = append(lines, values...)
+	}
+
+	return writeLines(lines)
+}
+
+// ExportHelmTemplate exports template snippet of container `env` list
+// which maps values generated by ExportHelmValues to environment variables,
+// extra entries are rendered as static values
+func (e *Exporter) ExportHelmTemplate(cfg interface{}) ([]byte, error) {
+	vars, err := e.variables(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	env := make([]string, 0)
+
+	for _, k := range e.extraEntryKeys() {
+		env = append(env, "- name: "+yamlString(k), "  value: "+yamlString(e.extraEntryValue(k)))
+	}
+
+	for i := range vars {
+		path := make([]string, 0)
+		if len(e.helmValuesPrefix) > 0 {
+			path = append(path, e.helmValuesPrefix)
+		}
+		for _, name := range strings.Split(vars[i].fieldPath, ".") {
+			path = append(path, lowerCamel(name))
+		}
+		env = append(env,
+			"- name: "+yamlString(vars[i].envVarName),
+			"  value: {{ .Values."+strings.Join(path, ".")+" | quote }}",
+		)
+	}
+
+	if len(env) == 0 {
+		return writeLines([]string{"env: []"})
+	}
+
+	return writeLines(append([]string{"env:"}, indentLines(env, "  ")...))
+}
+
+// hasDescendants reports whether group item is followed by any of its members
+func hasDescendants(items []cfgItem, i int) bool {
+	for j := i + 1; j < len(items); j++ {
+		if len(items[j].fieldPath) == 0 {
+			continue
+		}
+		return strings.HasPrefix(items[j].fieldPath, items[i].fieldPath+".")
+	}
+	return false
+}
+
+// helmKey returns values key for last element of field path
+func helmKey(path string) string {
+	return lowerCamel(path[strings.LastIndex(path, ".")+1:])
+}
+
+// lowerCamel converts exported Go identifier to lowerCamelCase,
+// leading acronyms are lowered as a whole, e.g. URLPath -> urlPath
+func lowerCamel(s string) string {
+	r := []rune(s)
+	n := 0
+	for n < len(r) && unicode.IsUpper(r[n]) {
+		n++
+	}
+	if n > 1 && n < len(r) {
+		n-- // last upper char starts next word
+	}
+	for i := 0; i < n; i++ {
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
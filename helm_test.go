@@ -0,0 +1,93 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigHelm struct {
+	Nested struct {
+		Foo       int8 `env:"NESTED_FOO" default:"98" desc:"Simple dummy value"`
+		NestedTwo struct {
+			URLPath string `env:"NESTED_URL_PATH" default:"/"`
+		}
+		Empty struct{}
+	}
+	APIKey string `env:"API_KEY" default:"key"`
+}
+
+func TestExportHelmValues(t *testing.T) {
+	e := New(WithHeaderText("# Test Header"))
+
+	d, err := e.ExportHelmValues(new(testConfigHelm))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Test Header
+
+nested:
+  # Foo (int8) Simple dummy value
+  foo: "98"
+  nestedTwo:
+    # URLPath (string)
+    urlPath: "/"
+  empty: {}
+# APIKey (string)
+apiKey: "key"
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportHelmValues_Prefix(t *testing.T) {
+	e := New(WithHeaderText(""), WithHelmValuesPrefix("config"))
+
+	d, err := e.ExportHelmValues(new(simpleStruct))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "config:\n  # A (string)\n  a: \"a\"\n  # B (int)\n  b: \"42\"\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportHelmTemplate(t *testing.T) {
+	e := New(
+		WithHelmValuesPrefix("config"),
+		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
+	)
+
+	d, err := e.ExportHelmTemplate(new(testConfigHelm))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `env:
+  - name: "COMPOSE_PROJECT_NAME"
+    value: "cfg2env"
+  - name: "NESTED_FOO"
+    value: {{ .Values.config.nested.foo | quote }}
+  - name: "NESTED_URL_PATH"
+    value: {{ .Values.config.nested.nestedTwo.urlPath | quote }}
+  - name: "API_KEY"
+    value: {{ .Values.config.apiKey | quote }}
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestLowerCamel(t *testing.T) {
+	cases := map[string]string{
+		"Foo":        "foo",
+		"NestedTwo":  "nestedTwo",
+		"URL":        "url",
+		"URLPath":    "urlPath",
+		"APIKey":     "apiKey",
+		"A":          "a",
+		"already":    "already",
+		"HTTPServer": "httpServer",
+	}
+	for in, out := range cases {
+		assert.Equal(t, out, lowerCamel(in))
+	}
+}
@@ -0,0 +1,61 @@
+package cfg2env
+
+import "strings"
+
+// options of control tag, e.g. `cfg2env:"hidden"`
+const (
+	// ControlSkip skips field or nested struct entirely
+	ControlSkip = "skip"
+	// ControlHidden keeps variable in manifest, but omits it from rendered output
+	ControlHidden = "hidden"
+)
+
+// hasControl reports whether control tag contains given option,
+// options are separated by comma
+func (e *Exporter) hasControl(tag MultilineStructTag, option string) bool {
+	for _, o := range strings.Split(tag.Get(e.controlTagName), ",") {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isSkipped reports whether field or nested struct is skipped by its tag,
+// either with `env:"-"` or `cfg2env:"skip"`
+func (e *Exporter) isSkipped(tag MultilineStructTag) bool {
+	return tag.Get(e.environmentTagName) == "-" || e.hasControl(tag, ControlSkip)
+}
+
+// hideItems marks items of hidden nested structs as hidden,
+// along with comments of hidden variables
+func hideItems(items []cfgItem) []cfgItem {
+	hidden := make(map[string]bool)
+	for i := range items {
+		if items[i].hidden {
+			hidden[items[i].fieldPath] = true
+		}
+	}
+	result := make([]cfgItem, len(items))
+	for i := range items {
+		result[i] = items[i]
+		for path := items[i].fieldPath; len(path) > 0; path = parentPath(path) {
+			if hidden[path] {
+				result[i].hidden = true
+				break
+			}
+		}
+	}
+	return result
+}
+
+// visibleItems drops hidden items
+func visibleItems(items []cfgItem) []cfgItem {
+	result := make([]cfgItem, 0, len(items))
+	for i := range items {
+		if !items[i].hidden {
+			result = append(result, items[i])
+		}
+	}
+	return result
+}
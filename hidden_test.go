@@ -0,0 +1,81 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigHidden struct {
+	A       string `env:"A" default:"a"`
+	Skipped string `env:"-" default:"skipped"`
+	Ignored string `env:"IGNORED" cfg2env:"skip"`
+	Token   string `env:"TOKEN" default:"t" cfg2env:"hidden" aliases:"OLD_TOKEN"`
+	Debug   struct {
+		Pprof bool `env:"DEBUG_PPROF"`
+	} `cfg2env:"hidden"`
+	Internal struct {
+		B string `env:"INTERNAL_B"`
+	} `env:"-"`
+	Nested struct {
+		C string `env:"NESTED_C" cfg2env:"skip, hidden"`
+		D string `env:"NESTED_D"`
+	}
+}
+
+func TestExportHidden(t *testing.T) {
+	data, err := New(WithHeaderText("")).Export(new(testConfigHidden))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# A (string)
+A=a
+
+## Nested
+
+# D (string)
+NESTED_D=
+`, string(data))
+}
+
+func TestManifestHidden(t *testing.T) {
+	m, err := New().Manifest(new(testConfigHidden))
+	if err != nil {
+		t.Error(err)
+	}
+
+	hidden := make(map[string]bool)
+	for _, v := range m.Variables {
+		hidden[v.Name] = v.Hidden
+	}
+	assert.Equal(t, map[string]bool{
+		"A":           false,
+		"TOKEN":       true,
+		"DEBUG_PPROF": true,
+		"NESTED_D":    false,
+	}, hidden)
+
+	data, migrations, err := New().Migrate(new(testConfigHidden), []byte("OLD_TOKEN=x\n"))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "TOKEN=x\n", string(data))
+	assert.Len(t, migrations, 1)
+}
+
+func TestDescribeHidden(t *testing.T) {
+	s := &Struct{Fields: []Field{
+		{Name: "A", Type: "string", Tag: `env:"A"`},
+		{Name: "B", Type: "string", Tag: `env:"-"`},
+		{Name: "Nested", Tag: `cfg2env:"skip"`, Struct: &Struct{Fields: []Field{
+			{Name: "C", Type: "string", Tag: `env:"C"`},
+		}}},
+	}}
+
+	data, err := New(WithHeaderText("")).Export(s)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "# A (string)\nA=\n", string(data))
+}
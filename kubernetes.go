@@ -0,0 +1,135 @@
+package cfg2env
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+)
+
+// ExportKubernetes exports struct as kubernetes v1/ConfigMap manifest,
+// secret fields are exported into separate v1/Secret manifest
+// with either placeholders or base64 encoded default values
+func (e *Exporter) ExportKubernetes(cfg interface{}) ([]byte, error) {
+	vars, err := e.variables(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		configMap = make([]variable, 0)
+		secret    = make([]variable, 0)
+	)
+
+	for i := range vars {
+		if vars[i].secret {
+			secret = append(secret, vars[i])
+		} else {
+			configMap = append(configMap, vars[i])
+		}
+	}
+
+	data := e.extraEntryLines(func(name string, value string) []string {
+		return []string{fmt.Sprintf("%s: %s", name, yamlString(value))}
+	})
+	data = append(data, e.kubernetesData(configMap, func(v variable) string {
+		return yamlString(v.defValue)
+	})...)
+
+	lines := e.headerLines()
+	lines = append(lines, e.kubernetesMetadata("ConfigMap")...)
+	lines = append(lines, yamlBlock("data", data)...)
+
+	if len(secret) > 0 {
+		lines = append(lines, "---")
+		lines = append(lines, e.kubernetesMetadata("Secret")...)
+		lines = append(lines, "type: Opaque")
+		if e.kubernetesSecretBase64 {
+			lines = append(lines, yamlBlock("data", e.kubernetesData(secret, func(v variable) string {
+				return yamlString(base64.StdEncoding.EncodeToString([]byte(v.defValue)))
+			}))...)
+		} else {
+			lines = append(lines, yamlBlock("stringData", e.kubernetesData(secret, func(v variable) string {
+				return yamlString(e.secretPlaceholder)
+			}))...)
+		}
+	}
+
+	return writeLines(lines)
+}
+
+// kubernetesMetadata renders manifest header of given kind
+func (e *Exporter) kubernetesMetadata(kind string) []string {
+	metadata := []string{"name: " + yamlString(e.kubernetesName)}
+	if len(e.kubernetesNamespace) > 0 {
+		metadata = append(metadata, "namespace: "+yamlString(e.kubernetesNamespace))
+	}
+	if len(e.kubernetesLabels) > 0 {
+		keys := make([]string, 0, len(e.kubernetesLabels))
+		for k := range e.kubernetesLabels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		labels := make([]string, 0, len(keys))
+		for _, k := range keys {
+			labels = append(labels, fmt.Sprintf("%s: %s", yamlString(k), yamlString(e.kubernetesLabels[k])))
+		}
+		metadata = append(metadata, yamlBlock("labels", labels)...)
+	}
+	lines := []string{"apiVersion: v1", "kind: " + kind}
+	return append(lines, yamlBlock("metadata", metadata)...)
+}
+
+// kubernetesData renders variables as data entries with
+// group headers and descriptions kept as comments
+func (e *Exporter) kubernetesData(vars []variable, value func(v variable) string) []string {
+	return e.variableLines(vars, func(v variable) []string {
+		return []string{fmt.Sprintf("%s: %s", v.envVarName, value(v))}
+	})
+}
+
+// ExportKubernetesEnv exports struct as `env` list of kubernetes container spec,
+// secret fields are referenced from Secret (see ExportKubernetes) and
+// required fields without default value are rendered with placeholders
+func (e *Exporter) ExportKubernetesEnv(cfg interface{}) ([]byte, error) {
+	vars, err := e.variables(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	env := e.extraEntryLines(func(name string, value string) []string {
+		return []string{"- name: " + yamlString(name), "  value: " + yamlString(value)}
+	})
+	env = append(env, e.variableLines(vars, func(v variable) []string {
+		switch {
+		case v.secret:
+			return []string{
+				"- name: " + yamlString(v.envVarName),
+				"  valueFrom:",
+				"    secretKeyRef:",
+				"      name: " + yamlString(e.kubernetesName),
+				"      key: " + yamlString(v.envVarName),
+			}
+		case v.required && len(v.defValue) == 0:
+			return []string{
+				"# " + e.requiredPlaceholder + ": required value",
+				"- name: " + yamlString(v.envVarName),
+				"  value: " + yamlString(e.requiredPlaceholder),
+			}
+		default:
+			return []string{
+				"- name: " + yamlString(v.envVarName),
+				"  value: " + yamlString(v.defValue),
+			}
+		}
+	})...)
+
+	lines := e.headerLines()
+	if len(env) == 0 {
+		lines = append(lines, "env: []")
+	} else {
+		lines = append(lines, "env:")
+		lines = append(lines, indentLines(env, "  ")...)
+	}
+
+	return writeLines(lines)
+}
@@ -0,0 +1,183 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigSecret struct {
+	Host     string `env:"DB_HOST" default:"localhost" desc:"Database host"`
+	Password string `env:"DB_PASSWORD" default:"qwerty" secret:"true"`
+	Cache    struct {
+		TTL   int    `env:"CACHE_TTL" default:"60"`
+		Token string `env:"CACHE_TOKEN" default:"token" secret:"true"`
+	}
+}
+
+func TestExportKubernetes(t *testing.T) {
+	e := New(
+		WithHeaderText("# Test Header"),
+		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
+		WithKubernetesName("app"),
+		WithKubernetesNamespace("prod"),
+		WithKubernetesLabel("app.kubernetes.io/name", "app"),
+	)
+
+	d, err := e.ExportKubernetes(new(testConfigSecret))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Test Header
+
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: "app"
+  namespace: "prod"
+  labels:
+    "app.kubernetes.io/name": "app"
+data:
+  # Extra pre-declared entries
+  COMPOSE_PROJECT_NAME: "cfg2env"
+  # Host (string) Database host
+  DB_HOST: "localhost"
+  ## Cache
+  # TTL (int)
+  CACHE_TTL: "60"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: "app"
+  namespace: "prod"
+  labels:
+    "app.kubernetes.io/name": "app"
+type: Opaque
+stringData:
+  # Password (string)
+  DB_PASSWORD: "CHANGE_ME"
+  ## Cache
+  # Token (string)
+  CACHE_TOKEN: "CHANGE_ME"
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportKubernetes_SecretBase64(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithKubernetesSecretBase64(true),
+	)
+
+	d, err := e.ExportKubernetes(new(testConfigSecret))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Contains(t, string(d), "data:\n  # Password (string)\n  DB_PASSWORD: \"cXdlcnR5\"\n")
+	assert.Contains(t, string(d), "  CACHE_TOKEN: \"dG9rZW4=\"\n")
+}
+
+func TestExportKubernetes_NoSecrets(t *testing.T) {
+	e := New(WithHeaderText(""))
+
+	d, err := e.ExportKubernetes(new(simpleStruct))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: \"config\"\ndata:\n  # A (string)\n  A: \"a\"\n  # B (int)\n  B: \"42\"\n"
+	assert.Equal(t, expected, string(d))
+}
+
+type testConfigRequired struct {
+	Host   string `env:"HOST" default:"localhost"`
+	APIKey string `env:"API_KEY" required:"true"`
+	Port   int    `env:"PORT" default:"80" required:"true"`
+}
+
+func TestExportKubernetesEnv(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
+		WithKubernetesName("app"),
+	)
+
+	d, err := e.ExportKubernetesEnv(new(testConfigSecret))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `env:
+  # Extra pre-declared entries
+  - name: "COMPOSE_PROJECT_NAME"
+    value: "cfg2env"
+  # Host (string) Database host
+  - name: "DB_HOST"
+    value: "localhost"
+  # Password (string)
+  - name: "DB_PASSWORD"
+    valueFrom:
+      secretKeyRef:
+        name: "app"
+        key: "DB_PASSWORD"
+  ## Cache
+  # TTL (int)
+  - name: "CACHE_TTL"
+    value: "60"
+  # Token (string)
+  - name: "CACHE_TOKEN"
+    valueFrom:
+      secretKeyRef:
+        name: "app"
+        key: "CACHE_TOKEN"
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportKubernetesEnv_Required(t *testing.T) {
+	e := New(WithHeaderText(""))
+
+	d, err := e.ExportKubernetesEnv(new(testConfigRequired))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `env:
+  # Host (string)
+  - name: "HOST"
+    value: "localhost"
+  # APIKey (string)
+  # TODO: required value
+  - name: "API_KEY"
+    value: "TODO"
+  # Port (int)
+  - name: "PORT"
+    value: "80"
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportKubernetesEnv_RequiredPlaceholder(t *testing.T) {
+	e := New(WithHeaderText(""), WithRequiredPlaceholder("FIXME"))
+
+	d, err := e.ExportKubernetesEnv(new(testConfigRequired))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Contains(t, string(d), "  # FIXME: required value\n  - name: \"API_KEY\"\n    value: \"FIXME\"\n")
+}
+
+func TestExportKubernetesEnv_Empty(t *testing.T) {
+	e := New(WithHeaderText(""))
+
+	d, err := e.ExportKubernetesEnv(&struct{}{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "env: []\n", string(d))
+}
@@ -0,0 +1,129 @@
+package cfg2env
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Layer is single layer of layered output, its values are either
+// current field values of configuration instance or default values of profile
+type Layer struct {
+	Name    string      // layer name, overlay file name is suffixed with it
+	Config  interface{} // pointer to configuration struct or *Struct description
+	Profile string      // profile of default values, see WithProfile
+	Values  bool        // use field values of Config, see WithFieldValues
+}
+
+// exporter returns copy of exporter configured for layer
+func (l Layer) exporter(e *Exporter) *Exporter {
+	p := e.withProfile(l.Profile)
+	p.fieldValues = l.Values
+	return p
+}
+
+// ExportLayers exports complete base file and overlay file for every
+// given layer, overlays contain only variables which values differ from base,
+// result of overlays is keyed by layer name
+func (e *Exporter) ExportLayers(base Layer, overlays ...Layer) ([]byte, map[string][]byte, error) {
+	baseItems, err := base.exporter(e).items(base.Config)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseData, err := base.exporter(e).render(baseItems)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make(map[string]string)
+	for i := range baseItems {
+		if len(baseItems[i].envVarName) > 0 {
+			values[baseItems[i].envVarName] = baseItems[i].defValue
+		}
+	}
+
+	result := make(map[string][]byte, len(overlays))
+	for _, overlay := range overlays {
+		p := overlay.exporter(e)
+		items, err := p.items(overlay.Config)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to export layer %s: %w", overlay.Name, err)
+		}
+		// extra entries are part of base file only
+		p.extraEntries = nil
+		data, err := p.render(overlayItems(items, values))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to export layer %s: %w", overlay.Name, err)
+		}
+		result[overlay.Name] = data
+	}
+
+	return baseData, result, nil
+}
+
+// ToFileLayers exports base file and overlay files, see ExportLayers,
+// overlay file name is suffixed with layer name, e.g. .env.prod
+func (e *Exporter) ToFileLayers(base Layer, overlays ...Layer) error {
+	baseData, data, err := e.ExportLayers(base, overlays...)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(e.fileName, baseData); err != nil {
+		return err
+	}
+	for _, overlay := range overlays {
+		if err := writeFile(e.fileName+"."+overlay.Name, data[overlay.Name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// overlayItems keeps variables which values differ from base values,
+// along with their comments and headers of groups they belong to
+func overlayItems(items []cfgItem, base map[string]string) []cfgItem {
+	changed := make(map[string]bool)
+	for i := range items {
+		if len(items[i].envVarName) == 0 {
+			continue
+		}
+		if v, ok := base[items[i].envVarName]; !ok || v != items[i].defValue {
+			changed[items[i].fieldPath] = true
+		}
+	}
+	return selectItems(items, changed)
+}
+
+// formatValue formats field value same way default values are written,
+// elements of slices and arrays are separated by comma,
+// map entries are written as key:value pairs sorted by key
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return formatValue(v.Elem())
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return fmt.Sprintf("%s", v.Interface())
+		}
+		values := make([]string, v.Len())
+		for i := range values {
+			values[i] = formatValue(v.Index(i))
+		}
+		return strings.Join(values, ",")
+	case reflect.Map:
+		values := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			values = append(values, formatValue(k)+":"+formatValue(v.MapIndex(k)))
+		}
+		sort.Strings(values)
+		return strings.Join(values, ",")
+	}
+	if v.CanInterface() {
+		return fmt.Sprint(v.Interface())
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package cfg2env
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigLayers struct {
+	LogLevel string `env:"LOG_LEVEL" default:"info" default.prod:"warn"`
+	Port     int    `env:"PORT" default:"8080"`
+	Database struct {
+		Host    string        `env:"DB_HOST" default:"localhost" default.prod:"db.internal"`
+		Timeout time.Duration `env:"DB_TIMEOUT" default:"5s"`
+	}
+	Cache struct {
+		Size int `env:"CACHE_SIZE" default:"100"`
+	}
+	Tags   []string         `env:"TAGS" default:"a,b"`
+	Limits map[string][]int `env:"LIMITS" default:"a:1,b:2"`
+}
+
+func TestExportLayersProfiles(t *testing.T) {
+	e := New(WithHeaderText(""), WithExtraEntry("EXTRA", "x"))
+
+	base, overlays, err := e.ExportLayers(
+		Layer{Config: new(testConfigLayers)},
+		Layer{Name: "prod", Config: new(testConfigLayers), Profile: "prod"},
+		Layer{Name: "dev", Config: new(testConfigLayers), Profile: "dev"},
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	d, err := e.Export(new(testConfigLayers))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, string(d), string(base))
+
+	assert.Equal(t, `# LogLevel (string)
+LOG_LEVEL=warn
+
+## Database
+
+# Host (string)
+DB_HOST=db.internal
+`, string(overlays["prod"]))
+	assert.Equal(t, ``, string(overlays["dev"]))
+}
+
+func TestExportLayersValues(t *testing.T) {
+	e := New(WithHeaderText("# Overlay"))
+
+	prod := new(testConfigLayers)
+	prod.LogLevel = "info"
+	prod.Port = 8080
+	prod.Database.Host = "localhost"
+	prod.Database.Timeout = 10 * time.Second
+	prod.Cache.Size = 100
+	prod.Tags = []string{"a", "c"}
+	prod.Limits = map[string][]int{"b": {2}, "a": {1}}
+
+	_, overlays, err := e.ExportLayers(
+		Layer{Config: new(testConfigLayers)},
+		Layer{Name: "prod", Config: prod, Values: true},
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `# Overlay
+
+## Database
+
+# Timeout (time.Duration)
+DB_TIMEOUT=10s
+
+## Root
+
+# Tags ([]string)
+TAGS=a,c
+`, string(overlays["prod"]))
+
+	assert.Equal(t, "", formatValue(reflect.ValueOf(map[string]int(nil))))
+	assert.Equal(t, "a:1,b:2,3", formatValue(reflect.ValueOf(map[string][]int{"b": {2, 3}, "a": {1}})))
+}
+
+func TestToFileLayers(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".env")
+	e := New(WithExportedFileName(name))
+
+	err := e.ToFileLayers(
+		Layer{Config: new(testConfigLayers)},
+		Layer{Name: "prod", Config: new(testConfigLayers), Profile: "prod"},
+	)
+	if err != nil {
+		t.Error(err)
+	}
+
+	d, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), "CACHE_SIZE=100")
+
+	d, err = os.ReadFile(name + ".prod")
+	assert.NoError(t, err)
+	assert.Contains(t, string(d), "LOG_LEVEL=warn")
+	assert.NotContains(t, string(d), "CACHE_SIZE")
+}
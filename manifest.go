@@ -0,0 +1,186 @@
+package cfg2env
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ManifestFormat is serialization format of exported manifest
+type ManifestFormat int
+
+const (
+	// ManifestJSON renders manifest as JSON document
+	ManifestJSON ManifestFormat = iota
+	// ManifestYAML renders manifest as YAML document
+	ManifestYAML
+)
+
+// Manifest is machine-readable description of all exported variables,
+// it can be consumed by other tools, or stored and compared with
+// later versions, see Changelog
+type Manifest struct {
+	Variables []ManifestVariable `json:"variables"`
+}
+
+// ManifestVariable is single variable of manifest
+type ManifestVariable struct {
+	Name        string            `json:"name"`                  // environment variable name
+	Path        string            `json:"path"`                  // path of struct field
+	Type        string            `json:"type,omitempty"`        // Go type as declared, e.g. []config.Level
+	Kind        string            `json:"kind,omitempty"`        // underlying type, e.g. []string
+	Default     string            `json:"default"`               // default value
+	Example     string            `json:"example,omitempty"`     // example value
+	Description string            `json:"description,omitempty"` // description from tag or doc comment
+	Group       string            `json:"group,omitempty"`       // title of group variable belongs to
+	ExtraTags   map[string]string `json:"extra_tags,omitempty"`  // values of extra tags
+	Required    bool              `json:"required"`              // variable is marked as required
+	Secret      bool              `json:"secret"`                // variable is marked as secret
+	DependsOn   string            `json:"depends_on,omitempty"`  // condition of variable, see dependsOn tag
+	Hidden      bool              `json:"hidden,omitempty"`      // variable is omitted from rendered output
+	Deprecated  string            `json:"deprecated,omitempty"`  // deprecation note
+	Aliases     []string          `json:"aliases,omitempty"`     // former names of variable
+}
+
+// Manifest exports struct as manifest,
+// cfg is either pointer to configuration struct or *Struct description
+func (e *Exporter) Manifest(cfg interface{}) (*Manifest, error) {
+	// manifest describes hidden variables as well
+	items, err := e.walk(cfg, true)
+	if err != nil {
+		return nil, err
+	}
+	vars := e.collectVariables(items)
+	m := &Manifest{Variables: make([]ManifestVariable, 0, len(vars))}
+	for i := range vars {
+		v := ManifestVariable{
+			Name:       vars[i].envVarName,
+			Path:       vars[i].fieldPath,
+			Default:    vars[i].defValue,
+			Required:   vars[i].required,
+			Secret:     vars[i].secret,
+			DependsOn:  vars[i].dependsOn,
+			Hidden:     vars[i].hidden,
+			Deprecated: vars[i].deprecated,
+			Aliases:    vars[i].aliases,
+		}
+		// root group header is only a separator, not a group
+		if len(parentPath(v.Path)) > 0 {
+			v.Group = strings.SplitN(vars[i].group, "\n", 2)[0]
+		}
+		if field := vars[i].field; field != nil {
+			v.Type, v.Kind = field.typeName, field.kind
+			v.Example = field.tag.Get(e.exampleTagName)
+			v.Description = field.tag.Get(e.descriptionTagName)
+			if len(v.Description) == 0 {
+				v.Description = field.doc
+			}
+			v.Description = trimLines(v.Description)
+			for _, tag := range e.extraTags {
+				if value := field.tag.Get(tag); len(value) > 0 {
+					if v.ExtraTags == nil {
+						v.ExtraTags = make(map[string]string)
+					}
+					v.ExtraTags[tag] = value
+				}
+			}
+		}
+		m.Variables = append(m.Variables, v)
+	}
+	return m, nil
+}
+
+// manifest returns manifest as is or exports configuration as manifest
+func (e *Exporter) manifest(v interface{}) (*Manifest, error) {
+	if m, ok := v.(*Manifest); ok {
+		return m, nil
+	}
+	return e.Manifest(v)
+}
+
+// ExportManifest exports struct as manifest in given format
+func (e *Exporter) ExportManifest(cfg interface{}, format ManifestFormat) ([]byte, error) {
+	m, err := e.Manifest(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case ManifestJSON:
+		data, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal manifest: %v", err)
+		}
+		return append(data, '\n'), nil
+	case ManifestYAML:
+		return writeLines(m.yamlLines())
+	default:
+		return nil, fmt.Errorf("unknown manifest format %d", format)
+	}
+}
+
+// yamlLines renders manifest as YAML, keys match JSON representation
+func (m *Manifest) yamlLines() []string {
+	if len(m.Variables) == 0 {
+		return []string{"variables: []"}
+	}
+	lines := []string{"variables:"}
+	for _, v := range m.Variables {
+		item := []string{
+			"name: " + yamlString(v.Name),
+			"path: " + yamlString(v.Path),
+		}
+		optional := func(key, value string) {
+			if len(value) > 0 {
+				item = append(item, key+": "+yamlString(value))
+			}
+		}
+		optional("type", v.Type)
+		optional("kind", v.Kind)
+		item = append(item, "default: "+yamlString(v.Default))
+		optional("example", v.Example)
+		optional("description", v.Description)
+		optional("group", v.Group)
+		if len(v.ExtraTags) > 0 {
+			keys := make([]string, 0, len(v.ExtraTags))
+			for k := range v.ExtraTags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			tags := make([]string, 0, len(keys))
+			for _, k := range keys {
+				tags = append(tags, k+": "+yamlString(v.ExtraTags[k]))
+			}
+			item = append(item, yamlBlock("extra_tags", tags)...)
+		}
+		item = append(item,
+			"required: "+strconv.FormatBool(v.Required),
+			"secret: "+strconv.FormatBool(v.Secret),
+		)
+		optional("depends_on", v.DependsOn)
+		if v.Hidden {
+			item = append(item, "hidden: true")
+		}
+		optional("deprecated", v.Deprecated)
+		if len(v.Aliases) > 0 {
+			item = append(item, "aliases:")
+			for _, alias := range v.Aliases {
+				item = append(item, "  - "+yamlString(alias))
+			}
+		}
+		lines = append(lines, "  - "+item[0])
+		lines = append(lines, indentLines(item[1:], "    ")...)
+	}
+	return lines
+}
+
+// trimLines trims spaces around every line of multi-line text
+func trimLines(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,122 @@
+package cfg2env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigManifest struct {
+	Host string `env:"HOST" default:"localhost" desc:"Server host
+	 used for listening" validate:"hostname"`
+	Password string `env:"PASSWORD" secret:"true" required:"true" aliases:"PASS"`
+	Database struct {
+		Timeout time.Duration `env:"DB_TIMEOUT" default:"5s" example:"10s"`
+	} `group:"Database"`
+	Levels []int8 `env:"LEVELS" deprecated:"not used"`
+}
+
+func TestManifest(t *testing.T) {
+	m, err := New(WithExtraTagExtraction("validate")).Manifest(new(testConfigManifest))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, []ManifestVariable{
+		{
+			Name: "HOST", Path: "Host", Type: "string", Kind: "string", Default: "localhost",
+			Description: "Server host\nused for listening", ExtraTags: map[string]string{"validate": "hostname"},
+		},
+		{
+			Name: "PASSWORD", Path: "Password", Type: "string", Kind: "string",
+			Required: true, Secret: true, Aliases: []string{"PASS"},
+		},
+		{
+			Name: "DB_TIMEOUT", Path: "Database.Timeout", Type: "time.Duration", Kind: "time.Duration",
+			Default: "5s", Example: "10s", Group: "Database",
+		},
+		{
+			Name: "LEVELS", Path: "Levels", Type: "[]int8", Kind: "[]int8",
+			Deprecated: "not used",
+		},
+	}, m.Variables)
+}
+
+func TestExportManifestJSON(t *testing.T) {
+	type config struct {
+		A string `env:"A" default:"a"`
+	}
+
+	data, err := New().ExportManifest(new(config), ManifestJSON)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `{
+  "variables": [
+    {
+      "name": "A",
+      "path": "A",
+      "type": "string",
+      "kind": "string",
+      "default": "a",
+      "required": false,
+      "secret": false
+    }
+  ]
+}
+`, string(data))
+}
+
+func TestExportManifestYAML(t *testing.T) {
+	data, err := New(WithExtraTagExtraction("validate")).ExportManifest(new(testConfigManifest), ManifestYAML)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, `variables:
+  - name: "HOST"
+    path: "Host"
+    type: "string"
+    kind: "string"
+    default: "localhost"
+    description: "Server host\nused for listening"
+    extra_tags:
+      validate: "hostname"
+    required: false
+    secret: false
+  - name: "PASSWORD"
+    path: "Password"
+    type: "string"
+    kind: "string"
+    default: ""
+    required: true
+    secret: true
+    aliases:
+      - "PASS"
+  - name: "DB_TIMEOUT"
+    path: "Database.Timeout"
+    type: "time.Duration"
+    kind: "time.Duration"
+    default: "5s"
+    example: "10s"
+    group: "Database"
+    required: false
+    secret: false
+  - name: "LEVELS"
+    path: "Levels"
+    type: "[]int8"
+    kind: "[]int8"
+    default: ""
+    required: false
+    secret: false
+    deprecated: "not used"
+`, string(data))
+
+	data, err = New().ExportManifest(&Struct{}, ManifestYAML)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "variables: []\n", string(data))
+}
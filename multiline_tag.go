@@ -1,6 +1,7 @@
 package cfg2env
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 )
@@ -94,3 +95,88 @@ func sanitizeName(s string) string {
 func escapeValue(s string) string {
 	return strconv.Quote(strings.Trim(s, `"`))
 }
+
+// TagPair is a single key:"value" pair of struct tag
+type TagPair struct {
+	Key   string
+	Value string
+}
+
+// TagSyntaxError describes position and reason of malformed struct tag
+type TagSyntaxError struct {
+	Offset int // byte offset in tag
+	Line   int // line in tag, starting from 1
+	Reason string
+}
+
+func (e *TagSyntaxError) Error() string {
+	return fmt.Sprintf("line %d, offset %d: %s", e.Line, e.Offset, e.Reason)
+}
+
+// Parse returns all key:"value" pairs of the tag in order of appearance.
+// Unlike Lookup, which silently ignores rest of the tag after syntax error,
+// Parse returns pairs parsed so far along with *TagSyntaxError.
+func (tag MultilineStructTag) Parse() ([]TagPair, error) {
+	var (
+		pairs  = make([]TagPair, 0)
+		s      = string(tag)
+		offset = 0
+	)
+
+	fail := func(i int, format string, args ...interface{}) ([]TagPair, error) {
+		return pairs, &TagSyntaxError{
+			Offset: i,
+			Line:   strings.Count(string(tag[:i]), "\n") + 1,
+			Reason: fmt.Sprintf(format, args...),
+		}
+	}
+
+	for {
+		// Skip leading whitespace.
+		i := 0
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n' || s[i] == '\r') {
+			i++
+		}
+		s, offset = s[i:], offset+i
+		if s == "" {
+			return pairs, nil
+		}
+
+		// Scan to colon, same way Lookup does.
+		i = 0
+		for i < len(s) && validChar(s[i]) && s[i] != ':' && s[i] != '"' && s[i] != 0x7f {
+			i++
+		}
+		name := sanitizeName(s[:i])
+		switch {
+		case len(name) == 0:
+			return fail(offset+i, "unexpected character %q, expected key", s[i])
+		case i >= len(s):
+			return fail(offset+i, "missing colon after key %q", name)
+		case s[i] != ':':
+			return fail(offset+i, "unexpected character %q after key %q, expected colon", s[i], name)
+		case i+1 >= len(s) || s[i+1] != '"':
+			return fail(offset+i+1, "value of key %q is not quoted", name)
+		}
+		s, offset = s[i+1:], offset+i+1
+
+		// Scan quoted string to find value.
+		i = 1
+		for i < len(s) && s[i] != '"' {
+			if s[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(s) {
+			return fail(offset, "unterminated value of key %q", name)
+		}
+
+		value, err := strconv.Unquote(escapeValue(s[:i+1]))
+		if err != nil {
+			return fail(offset, "invalid value of key %q: %v", name, err)
+		}
+		pairs = append(pairs, TagPair{Key: name, Value: value})
+		s, offset = s[i+1:], offset+i+1
+	}
+}
@@ -27,3 +27,48 @@ func TestMultilineTag(t *testing.T) {
 		tag.Get("desc"),
 	)
 }
+
+func TestMultilineTagParse(t *testing.T) {
+	tag := MultilineStructTag(`
+			env:"A"
+			 default:"def_value_of_a"
+			desc:"just a dummy text
+			on two lines" env:"B"`)
+
+	pairs, err := tag.Parse()
+	assert.NoError(t, err)
+	assert.Equal(t, []TagPair{
+		{Key: "env", Value: "A"},
+		{Key: "default", Value: "def_value_of_a"},
+		{Key: "desc", Value: "just a dummy text\n\t\t\ton two lines"},
+		{Key: "env", Value: "B"},
+	}, pairs)
+
+	pairs, err = MultilineStructTag("").Parse()
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 0)
+}
+
+func TestMultilineTagParse_Errors(t *testing.T) {
+	cases := []struct {
+		tag    string
+		pairs  int
+		offset int
+		line   int
+		reason string
+	}{
+		{`env:"A" :"B"`, 1, 8, 1, `unexpected character ':', expected key`},
+		{"env:\"A\"\n\tdefault", 1, 16, 2, `missing colon after key "default"`},
+		{"env:\"A\"\n\tdefault \"a\"", 1, 17, 2, `unexpected character '"' after key "default", expected colon`},
+		{`env:"A" default:a`, 1, 16, 1, `value of key "default" is not quoted`},
+		{"env:\"A\"\n\n desc:\"never ends", 1, 15, 3, `unterminated value of key "desc"`},
+	}
+	for _, c := range cases {
+		pairs, err := MultilineStructTag(c.tag).Parse()
+		assert.Len(t, pairs, c.pairs, c.tag)
+		assert.Equal(t, &TagSyntaxError{Offset: c.offset, Line: c.line, Reason: c.reason}, err, c.tag)
+	}
+
+	_, err := MultilineStructTag(`env:A`).Parse()
+	assert.EqualError(t, err, `line 1, offset 4: value of key "env" is not quoted`)
+}
@@ -46,6 +46,15 @@ func WithExcludedFields(fields ...string) Option {
 	}
 }
 
+// WithDefaultExcludedFields keeps fields excluded by default,
+// disable it to match embedded mutex by type instead, see WithExcludedTypes
+// Default: true
+func WithDefaultExcludedFields(v bool) Option {
+	return func(e *Exporter) {
+		e.defaultExcluded = v
+	}
+}
+
 // WithHeaderText changes default header for generated file
 // Can be set to empty string to disable header
 // Default: `# Default configuration`
@@ -68,3 +77,329 @@ func WithExtraTagExtraction(tag string) Option {
 		e.extraTags = append(e.extraTags, tag)
 	}
 }
+
+// WithSecretTagName sets custom tag name to determine secret fields
+// Secret fields are marked with boolean value, e.g. `secret:"true"`
+// Default: secret
+func WithSecretTagName(v string) Option {
+	return func(e *Exporter) {
+		e.secretTagName = v
+	}
+}
+
+// WithRequiredTagName sets custom tag name to determine required fields
+// Required fields are marked with boolean value, e.g. `required:"true"`
+// Default: required
+func WithRequiredTagName(v string) Option {
+	return func(e *Exporter) {
+		e.requiredTagName = v
+	}
+}
+
+// WithSecretPlaceholder sets value rendered instead of secret default values
+// Default: CHANGE_ME
+func WithSecretPlaceholder(v string) Option {
+	return func(e *Exporter) {
+		e.secretPlaceholder = v
+	}
+}
+
+// WithRequiredPlaceholder sets value rendered for required fields without default value
+// Default: TODO
+func WithRequiredPlaceholder(v string) Option {
+	return func(e *Exporter) {
+		e.requiredPlaceholder = v
+	}
+}
+
+// WithKubernetesName sets name of generated ConfigMap and Secret
+// Default: config
+func WithKubernetesName(v string) Option {
+	return func(e *Exporter) {
+		e.kubernetesName = v
+	}
+}
+
+// WithKubernetesNamespace sets namespace of generated ConfigMap and Secret
+// Namespace is omitted if not set
+func WithKubernetesNamespace(v string) Option {
+	return func(e *Exporter) {
+		e.kubernetesNamespace = v
+	}
+}
+
+// WithKubernetesLabel adds label to generated ConfigMap and Secret
+func WithKubernetesLabel(key string, value string) Option {
+	return func(e *Exporter) {
+		e.kubernetesLabels[key] = value
+	}
+}
+
+// WithKubernetesSecretBase64 renders base64 encoded default values
+// of secret fields instead of placeholders
+func WithKubernetesSecretBase64(v bool) Option {
+	return func(e *Exporter) {
+		e.kubernetesSecretBase64 = v
+	}
+}
+
+// WithComposeListSyntax renders docker compose environment section
+// as list of `VAR=value` items instead of mapping
+func WithComposeListSyntax(v bool) Option {
+	return func(e *Exporter) {
+		e.composeListSyntax = v
+	}
+}
+
+// WithHelmValuesPrefix nests generated helm values under given key
+// Default: values are placed at top level
+func WithHelmValuesPrefix(v string) Option {
+	return func(e *Exporter) {
+		e.helmValuesPrefix = v
+	}
+}
+
+// WithStrictTags makes export fail on malformed struct tags,
+// by default rest of malformed tag is silently ignored
+func WithStrictTags(v bool) Option {
+	return func(e *Exporter) {
+		e.strictTags = v
+	}
+}
+
+// WithCommentWidth wraps comment lines longer than given width at word boundaries,
+// items of lists are wrapped with continuation lines aligned to item text
+// Default: 0 (no wrapping)
+func WithCommentWidth(v int) Option {
+	return func(e *Exporter) {
+		e.commentWidth = v
+	}
+}
+
+// WithCommentPreserveIndent removes common indentation of multi-line descriptions
+// instead of collapsing all whitespace, so lists and code examples are preserved
+func WithCommentPreserveIndent(v bool) Option {
+	return func(e *Exporter) {
+		e.commentIndent = v
+	}
+}
+
+// WithCommentStripMarkdown strips inline markdown from descriptions:
+// emphasis, inline code and links, which are rendered as `text (url)`
+// Default: false
+func WithCommentStripMarkdown(v bool) Option {
+	return func(e *Exporter) {
+		e.commentMarkdown = v
+	}
+}
+
+// WithCommentFormatter sets custom function which formats description
+// and group header text, every line of result is prefixed with `# `
+func WithCommentFormatter(f func(s string) string) Option {
+	return func(e *Exporter) {
+		e.commentFormatter = f
+	}
+}
+
+// WithTemplate sets text/template used to render .env file,
+// template receives TemplateModel and can use helper functions:
+// quote, comment, group, upper and lower
+// Default: DefaultTemplate
+func WithTemplate(t string) Option {
+	return func(e *Exporter) {
+		e.template = t
+	}
+}
+
+// WithDescriptionLayout sets which parts variable description consists of and their order,
+// extra tags are rendered on separate lines unless DescriptionExtraTags is part of layout
+// Default: DescriptionFieldName, DescriptionGoType, DescriptionText
+func WithDescriptionLayout(parts ...DescriptionPart) Option {
+	return func(e *Exporter) {
+		e.descriptionLayout = parts
+	}
+}
+
+// WithGroupTagName sets custom tag name of nested struct title,
+// which is used in group header instead of field path
+// Default: group
+func WithGroupTagName(v string) Option {
+	return func(e *Exporter) {
+		e.groupTagName = v
+	}
+}
+
+// WithOmitEmptyGroups omits headers of nested groups which
+// have no own variables, e.g. pure container structs
+func WithOmitEmptyGroups(v bool) Option {
+	return func(e *Exporter) {
+		e.omitEmptyGroups = v
+	}
+}
+
+// WithRootGroupTitle sets header title of top level fields,
+// which is used when they follow nested groups
+// Default: Root
+func WithRootGroupTitle(v string) Option {
+	return func(e *Exporter) {
+		e.rootGroupTitle = v
+	}
+}
+
+// WithGroupOrder sets order of scalar fields and nested groups within struct
+// Default: GroupOrderDeclaration
+func WithGroupOrder(v GroupOrder) Option {
+	return func(e *Exporter) {
+		e.groupOrder = v
+	}
+}
+
+// WithProfile makes exporter use profile-scoped default values,
+// e.g. `default.prod:"warn"` for profile prod, falling back to base default
+// Default: none, base default values are used
+func WithProfile(v string) Option {
+	return func(e *Exporter) {
+		e.profile = v
+	}
+}
+
+// WithFieldValues makes exporter use current values of configuration
+// instance fields instead of default values from tags
+// Default: false
+func WithFieldValues(v bool) Option {
+	return func(e *Exporter) {
+		e.fieldValues = v
+	}
+}
+
+// WithExampleTagName sets tag name used to extract example value
+// Default: example
+func WithExampleTagName(v string) Option {
+	return func(e *Exporter) {
+		e.exampleTagName = v
+	}
+}
+
+// WithExampleFile makes ToFile also write example file, e.g. .env.example,
+// see ExportExample
+// Default: false
+func WithExampleFile(v bool) Option {
+	return func(e *Exporter) {
+		e.exampleFile = v
+	}
+}
+
+// WithDeprecatedTagName sets tag name used to extract deprecation note
+// Default: deprecated
+func WithDeprecatedTagName(v string) Option {
+	return func(e *Exporter) {
+		e.deprecatedTagName = v
+	}
+}
+
+// WithAliasesTagName sets tag name used to extract former variable names
+// Default: aliases
+func WithAliasesTagName(v string) Option {
+	return func(e *Exporter) {
+		e.aliasesTagName = v
+	}
+}
+
+// WithDependsOnTagName sets tag name used to extract condition of variable
+// or nested group, e.g. `dependsOn:"TRACING_ENABLED"` or `dependsOn:"MODE=cluster"`
+// Default: dependsOn
+func WithDependsOnTagName(v string) Option {
+	return func(e *Exporter) {
+		e.dependsOnTagName = v
+	}
+}
+
+// WithCommentOutDisabled makes exporter comment out variables
+// which conditions are not met by default values
+// Default: false
+func WithCommentOutDisabled(v bool) Option {
+	return func(e *Exporter) {
+		e.commentOutDisabled = v
+	}
+}
+
+// WithFeatures makes exporter export only variables without condition
+// and variables depending on given controlling variables (features)
+// Default: all variables are exported
+func WithFeatures(v ...string) Option {
+	return func(e *Exporter) {
+		e.features = make(map[string]bool, len(v))
+		for i := range v {
+			e.features[v[i]] = true
+		}
+	}
+}
+
+// WithIncludedPaths exports only fields matching any of path glob patterns,
+// e.g. `Nested.*` or `**.Port`, see PathFilter
+// Default: all fields are exported
+func WithIncludedPaths(patterns ...string) Option {
+	return WithIncludeFilter(PathFilter(patterns...))
+}
+
+// WithExcludedPaths excludes fields matching any of path glob patterns,
+// e.g. `Nested.Foo` or `**.Internal`, see PathFilter
+func WithExcludedPaths(patterns ...string) Option {
+	return WithExcludeFilter(PathFilter(patterns...))
+}
+
+// WithIncludedTypes exports only fields of given Go types, e.g. time.Duration
+// Default: all fields are exported
+func WithIncludedTypes(typeNames ...string) Option {
+	return WithIncludeFilter(TypeFilter(typeNames...))
+}
+
+// WithExcludedTypes excludes fields of given Go types, e.g. sync.Mutex
+func WithExcludedTypes(typeNames ...string) Option {
+	return WithExcludeFilter(TypeFilter(typeNames...))
+}
+
+// WithIncludeFilter exports only fields matching any of include filters,
+// nested structs are kept if any of their fields is included
+// Default: all fields are exported
+func WithIncludeFilter(f FieldFilter) Option {
+	return func(e *Exporter) {
+		e.includeFilters = append(e.includeFilters, f)
+	}
+}
+
+// WithExcludeFilter excludes fields matching filter, e.g. by tag value,
+// excluded nested struct is excluded along with all its fields
+func WithExcludeFilter(f FieldFilter) Option {
+	return func(e *Exporter) {
+		e.excludeFilters = append(e.excludeFilters, f)
+	}
+}
+
+// WithControlTagName sets tag name used to control export of field,
+// `cfg2env:"skip"` skips field or nested struct, `cfg2env:"hidden"`
+// omits it from rendered output, keeping it in manifest
+// Default: cfg2env
+func WithControlTagName(v string) Option {
+	return func(e *Exporter) {
+		e.controlTagName = v
+	}
+}
+
+// WithFileTagName sets tag name used to route variable or nested struct
+// into separate file, e.g. `file:"db.env"`, see ExportFiles
+// Default: file
+func WithFileTagName(v string) Option {
+	return func(e *Exporter) {
+		e.fileTagName = v
+	}
+}
+
+// WithSplitByGroup routes every top-level nested struct into separate file
+// named after struct field, e.g. Database into database.env, see ExportFiles
+// Default: false
+func WithSplitByGroup(v bool) Option {
+	return func(e *Exporter) {
+		e.splitByGroup = v
+	}
+}
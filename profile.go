@@ -0,0 +1,51 @@
+package cfg2env
+
+import "fmt"
+
+// defaultValue returns default value of field for current profile,
+// profile-scoped tag (e.g. `default.prod`) takes precedence over base one
+func (e *Exporter) defaultValue(tag MultilineStructTag) string {
+	if len(e.profile) > 0 {
+		if v, ok := tag.Lookup(e.defaultValueTagName + "." + e.profile); ok {
+			return v
+		}
+	}
+	return tag.Get(e.defaultValueTagName)
+}
+
+// withProfile returns copy of exporter using defaults of given profile
+func (e *Exporter) withProfile(profile string) *Exporter {
+	p := *e
+	p.profile = profile
+	return &p
+}
+
+// ExportProfiles exports struct once per profile, see Export,
+// profile defaults are taken from tags like `default.prod:"warn"`,
+// fields without profile-scoped tag use base default value
+func (e *Exporter) ExportProfiles(cfg interface{}, profiles ...string) (map[string][]byte, error) {
+	result := make(map[string][]byte, len(profiles))
+	for _, profile := range profiles {
+		data, err := e.withProfile(profile).Export(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export profile %s: %w", profile, err)
+		}
+		result[profile] = data
+	}
+	return result, nil
+}
+
+// ToFileProfiles exports struct into one file per profile,
+// file name is suffixed with profile name, e.g. .env.prod
+func (e *Exporter) ToFileProfiles(cfg interface{}, profiles ...string) error {
+	data, err := e.ExportProfiles(cfg, profiles...)
+	if err != nil {
+		return err
+	}
+	for _, profile := range profiles {
+		if err := writeFile(e.fileName+"."+profile, data[profile]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package cfg2env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigProfiles struct {
+	LogLevel string `env:"LOG_LEVEL" default:"info" default.dev:"debug" default.prod:"warn"`
+	Port     int    `env:"PORT" default:"8080"`
+	Debug    bool   `env:"DEBUG" default.dev:"true"`
+}
+
+func TestExportProfiles(t *testing.T) {
+	e := New(WithHeaderText(""))
+
+	data, err := e.ExportProfiles(new(testConfigProfiles), "dev", "prod")
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t,
+		"# LogLevel (string)\nLOG_LEVEL=debug\n# Port (int)\nPORT=8080\n# Debug (bool)\nDEBUG=true\n",
+		string(data["dev"]),
+	)
+	assert.Equal(t,
+		"# LogLevel (string)\nLOG_LEVEL=warn\n# Port (int)\nPORT=8080\n# Debug (bool)\nDEBUG=\n",
+		string(data["prod"]),
+	)
+
+	// exporter itself is not affected
+	d, err := e.Export(new(testConfigProfiles))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, "# LogLevel (string)\nLOG_LEVEL=info\n# Port (int)\nPORT=8080\n# Debug (bool)\nDEBUG=\n", string(d))
+
+	d, err = New(WithHeaderText(""), WithProfile("prod")).Export(new(testConfigProfiles))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, string(data["prod"]), string(d))
+}
+
+func TestToFileProfiles(t *testing.T) {
+	name := filepath.Join(t.TempDir(), ".env")
+	e := New(WithExportedFileName(name))
+
+	if err := e.ToFileProfiles(new(testConfigProfiles), "dev", "prod"); err != nil {
+		t.Error(err)
+	}
+
+	for _, profile := range []string{"dev", "prod"} {
+		d, err := os.ReadFile(name + "." + profile)
+		assert.NoError(t, err)
+		assert.Contains(t, string(d), "LOG_LEVEL=")
+	}
+
+	_, err := os.Stat(name)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,187 @@
+package cfg2env
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// variable is a variable definition along with comments describing it,
+// it is used by renderers which need variables rather than raw items
+type variable struct {
+	cfgItem
+	group    string   // comment of the group variable belongs to
+	comments []string // formatted comment lines preceding the variable
+}
+
+// collectVariables folds exported items into variables,
+// attaching preceding comments and parent group to every definition
+func (e *Exporter) collectVariables(items []cfgItem) []variable {
+	var (
+		vars     = make([]variable, 0)
+		groups   = make(map[string]string)
+		comments = make([]string, 0)
+	)
+	for i := range items {
+		switch {
+		case items[i].nestedGroup:
+			groups[items[i].fieldPath] = items[i].comment
+		case len(items[i].comment) > 0:
+			comments = append(comments, strings.Split(e.comment(items[i].comment, false), "\n")...)
+		default:
+			vars = append(vars, variable{
+				cfgItem:  items[i],
+				group:    groups[parentPath(items[i].fieldPath)],
+				comments: comments,
+			})
+			comments = make([]string, 0)
+		}
+	}
+	return vars
+}
+
+// variables exports struct data as variables
+func (e *Exporter) variables(cfg interface{}) ([]variable, error) {
+	items, err := e.items(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return e.collectVariables(items), nil
+}
+
+// selectItems keeps variables with given field paths along with their comments
+// and headers of groups they belong to
+func selectItems(items []cfgItem, paths map[string]bool) []cfgItem {
+	result := make([]cfgItem, 0, len(paths))
+	for i := range items {
+		switch {
+		case items[i].nestedGroup:
+			for path := range paths {
+				if strings.HasPrefix(path, items[i].fieldPath+".") {
+					result = append(result, items[i])
+					break
+				}
+			}
+		case paths[items[i].fieldPath]:
+			result = append(result, items[i])
+		}
+	}
+	return result
+}
+
+// extraEntryLines renders extra entries under comment header,
+// entry renders lines of single entry
+func (e *Exporter) extraEntryLines(entry func(name string, value string) []string) []string {
+	lines := make([]string, 0)
+	if len(e.extraEntries) == 0 {
+		return lines
+	}
+	lines = append(lines, "# Extra pre-declared entries")
+	for _, k := range e.extraEntryKeys() {
+		lines = append(lines, entry(k, e.extraEntryValue(k))...)
+	}
+	return lines
+}
+
+// variableLines renders variables with group headers and descriptions
+// kept as comments, definition renders lines of single variable,
+// header of parent group (or root group) is repeated by title only
+// when variables follow nested groups, see resumeGroups
+func (e *Exporter) variableLines(vars []variable, definition func(v variable) []string) []string {
+	var (
+		lines   = make([]string, 0)
+		seen    = make(map[string]bool)
+		current string
+	)
+	for i := range vars {
+		if path := parentPath(vars[i].fieldPath); path != current {
+			switch {
+			case len(path) == 0:
+				if len(e.rootGroupTitle) > 0 {
+					lines = append(lines, e.groupComment(e.rootGroupTitle)...)
+				}
+			case seen[path]:
+				lines = append(lines, e.groupComment(strings.SplitN(vars[i].group, "\n", 2)[0])...)
+			case len(vars[i].group) > 0:
+				lines = append(lines, e.groupComment(vars[i].group)...)
+			}
+			seen[path] = true
+			current = path
+		}
+		lines = append(lines, vars[i].comments...)
+		lines = append(lines, definition(vars[i])...)
+	}
+	return lines
+}
+
+// parentPath strips last element from field path
+func parentPath(path string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// groupComment formats group header as comment lines
+func (e *Exporter) groupComment(s string) []string {
+	return strings.Split(e.comment(s, true), "\n")
+}
+
+// headerLines returns header text followed by empty line
+func (e *Exporter) headerLines() []string {
+	if len(e.headerText) == 0 {
+		return make([]string, 0)
+	}
+	return []string{e.headerText, ""}
+}
+
+// extraEntryKeys returns keys of extra entries in sorted order
+func (e *Exporter) extraEntryKeys() []string {
+	keys := make([]string, 0, len(e.extraEntries))
+	for k := range e.extraEntries {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// extraEntryValue returns extra entry value as string
+func (e *Exporter) extraEntryValue(key string) string {
+	return fmt.Sprintf("%v", e.extraEntries[key])
+}
+
+// writeLines joins lines and returns them as resulting data
+func writeLines(lines []string) ([]byte, error) {
+	var b strings.Builder
+	for i := range lines {
+		if _, err := b.WriteString(lines[i] + "\n"); err != nil {
+			return nil, fmt.Errorf("failed to write to buffer: %v", err)
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// yamlString quotes string as double-quoted yaml scalar
+func yamlString(s string) string {
+	return strconv.Quote(s)
+}
+
+// yamlBlock renders mapping under given key with indented lines
+func yamlBlock(key string, lines []string) []string {
+	if len(lines) == 0 {
+		return []string{key + ": {}"}
+	}
+	return append([]string{key + ":"}, indentLines(lines, "  ")...)
+}
+
+// indentLines prefixes every non-empty line with indent
+func indentLines(lines []string, indent string) []string {
+	result := make([]string, len(lines))
+	for i := range lines {
+		if len(lines[i]) > 0 {
+			result[i] = indent + lines[i]
+		}
+	}
+	return result
+}
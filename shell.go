@@ -0,0 +1,66 @@
+package cfg2env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Shell represents shell dialect of exported script
+type Shell int
+
+const (
+	// ShellPOSIX renders `export VAR='value'` for sh, bash and zsh
+	ShellPOSIX Shell = iota
+	// ShellFish renders `set -gx VAR 'value'` for fish
+	ShellFish
+	// ShellPowerShell renders `$env:VAR = "value"` for PowerShell
+	ShellPowerShell
+)
+
+// ExportShell exports struct as script which can be sourced by given shell
+func (e *Exporter) ExportShell(cfg interface{}, shell Shell) ([]byte, error) {
+	vars, err := e.variables(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := shellStatement(shell)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := e.headerLines()
+	lines = append(lines, e.extraEntryLines(func(name string, value string) []string {
+		return []string{statement(name, value)}
+	})...)
+	lines = append(lines, e.variableLines(vars, func(v variable) []string {
+		return []string{statement(v.envVarName, v.defValue)}
+	})...)
+
+	return writeLines(lines)
+}
+
+// shellStatement returns function rendering variable assignment for given shell
+func shellStatement(shell Shell) (func(name string, value string) string, error) {
+	switch shell {
+	case ShellPOSIX:
+		return func(name string, value string) string {
+			return fmt.Sprintf("export %s='%s'", name, strings.ReplaceAll(value, `'`, `'\''`))
+		}, nil
+	case ShellFish:
+		return func(name string, value string) string {
+			value = strings.ReplaceAll(value, `\`, `\\`)
+			value = strings.ReplaceAll(value, `'`, `\'`)
+			return fmt.Sprintf("set -gx %s '%s'", name, value)
+		}, nil
+	case ShellPowerShell:
+		return func(name string, value string) string {
+			value = strings.ReplaceAll(value, "`", "``")
+			value = strings.ReplaceAll(value, `"`, "`\"")
+			value = strings.ReplaceAll(value, `$`, "`$")
+			return fmt.Sprintf(`$env:%s = "%s"`, name, value)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown shell: %d", shell)
+	}
+}
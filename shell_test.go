@@ -0,0 +1,88 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigShell struct {
+	Quote  string `env:"QUOTE" default:"it's quoted" desc:"Value with quotes"`
+	Nested struct {
+		Special string `env:"SPECIAL" default:"$HOME/bin"`
+	}
+}
+
+func TestExportShell_POSIX(t *testing.T) {
+	e := New(
+		WithHeaderText("# Test Header"),
+		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
+	)
+
+	d, err := e.ExportShell(new(testConfigShell), ShellPOSIX)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Test Header
+
+# Extra pre-declared entries
+export COMPOSE_PROJECT_NAME='cfg2env'
+# Quote (string) Value with quotes
+export QUOTE='it'\''s quoted'
+## Nested
+# Special (string)
+export SPECIAL='$HOME/bin'
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportShell_Fish(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithExtraEntry("ESCAPED", `"\'`),
+	)
+
+	d, err := e.ExportShell(new(testConfigShell), ShellFish)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Extra pre-declared entries
+set -gx ESCAPED '"\\\''
+# Quote (string) Value with quotes
+set -gx QUOTE 'it\'s quoted'
+## Nested
+# Special (string)
+set -gx SPECIAL '$HOME/bin'
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportShell_PowerShell(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithExtraEntry("ESCAPED", "\"`"),
+	)
+
+	d, err := e.ExportShell(new(testConfigShell), ShellPowerShell)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# Extra pre-declared entries\n" +
+		"$env:ESCAPED = \"`\"``\"\n" +
+		"# Quote (string) Value with quotes\n" +
+		"$env:QUOTE = \"it's quoted\"\n" +
+		"## Nested\n" +
+		"# Special (string)\n" +
+		"$env:SPECIAL = \"`$HOME/bin\"\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportShell_Unknown(t *testing.T) {
+	e := New()
+
+	_, err := e.ExportShell(new(testConfigShell), Shell(42))
+	assert.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package cfg2env
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// fileOf returns name of file item is routed to: file tag of field or of
+// closest parent struct, otherwise lowercased name of top-level struct
+// if WithSplitByGroup is enabled, empty for main file
+func (e *Exporter) fileOf(path string, files map[string]string) string {
+	for p := path; len(p) > 0; p = parentPath(p) {
+		if f, ok := files[p]; ok {
+			return f
+		}
+	}
+	if e.splitByGroup {
+		if top, _, nested := strings.Cut(path, "."); nested {
+			return strings.ToLower(top) + filepath.Ext(e.fileName)
+		}
+	}
+	return ""
+}
+
+// ExportFiles exports struct into several files, see WithSplitByGroup and file tag,
+// result is keyed by file name, variables which are not routed to other files
+// are kept in main file (see WithExportedFileName), which also holds header
+// and extra entries; if variables are split, index file listing variables of
+// every file is added, named after main file with .index suffix, e.g. .env.index
+func (e *Exporter) ExportFiles(cfg interface{}) (map[string][]byte, error) {
+	items, err := e.items(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		main   = filepath.Base(e.fileName)
+		files  = make(map[string]string)
+		routed = make(map[string]map[string]bool)
+		names  = make(map[string][]string)
+	)
+	for i := range items {
+		if len(items[i].file) > 0 {
+			files[items[i].fieldPath] = items[i].file
+		}
+	}
+	for i := range items {
+		if len(items[i].envVarName) == 0 {
+			continue
+		}
+		name := e.fileOf(items[i].fieldPath, files)
+		if len(name) == 0 {
+			name = main
+		}
+		if routed[name] == nil {
+			routed[name] = make(map[string]bool)
+		}
+		routed[name][items[i].fieldPath] = true
+		names[name] = append(names[name], items[i].envVarName)
+	}
+
+	result := make(map[string][]byte, len(routed)+2)
+	if len(routed) == 0 || (len(routed) == 1 && routed[main] != nil) {
+		data, err := e.render(items)
+		if err != nil {
+			return nil, err
+		}
+		result[main] = data
+		return result, nil
+	}
+
+	for name, paths := range routed {
+		p := *e
+		if name != main {
+			// extra entries are part of main file only
+			p.extraEntries = nil
+		}
+		data, err := p.render(selectItems(items, paths))
+		if err != nil {
+			return nil, err
+		}
+		result[name] = data
+	}
+	if _, ok := result[main]; !ok {
+		data, err := e.render(nil)
+		if err != nil {
+			return nil, err
+		}
+		result[main] = data
+	}
+
+	index, err := splitIndex(main, names)
+	if err != nil {
+		return nil, err
+	}
+	result[main+".index"] = index
+
+	return result, nil
+}
+
+// splitIndex lists variables of every file, main file goes first
+func splitIndex(main string, names map[string][]string) ([]byte, error) {
+	files := make([]string, 0, len(names))
+	for name := range names {
+		if name != main {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	if _, ok := names[main]; ok {
+		files = append([]string{main}, files...)
+	}
+
+	lines := make([]string, 0)
+	for i, name := range files {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, "# "+name)
+		lines = append(lines, names[name]...)
+	}
+	return writeLines(lines)
+}
+
+// toFiles writes all files exported by ExportFiles
+// next to main file, see WithExportedFileName
+func (e *Exporter) toFiles(files map[string][]byte) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeFile(filepath.Join(filepath.Dir(e.fileName), name), files[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
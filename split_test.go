@@ -0,0 +1,117 @@
+package cfg2env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigSplit struct {
+	Name     string `env:"NAME" default:"app"`
+	Database struct {
+		Host string `env:"DB_HOST" default:"localhost"`
+		Pool struct {
+			Size int `env:"DB_POOL_SIZE" default:"10"`
+		}
+	}
+	Cache struct {
+		TTL  string `env:"CACHE_TTL" default:"1m"`
+		Peer string `env:"CACHE_PEER" file:"cluster.env"`
+	}
+	Queue struct {
+		URL string `env:"QUEUE_URL"`
+	} `file:"cluster.env"`
+}
+
+func TestExportFilesByGroup(t *testing.T) {
+	files, err := New(WithHeaderText("# Header"), WithExtraEntry("EXTRA", 1), WithSplitByGroup(true)).
+		ExportFiles(new(testConfigSplit))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Len(t, files, 5)
+	assert.Equal(t, "# Header\n\n# Extra pre-declared entries\nEXTRA=1\n\n# Name (string)\nNAME=app\n", string(files[".env"]))
+	assert.Equal(t, `# Header
+
+## Database
+
+# Host (string)
+DB_HOST=localhost
+
+## Database.Pool
+
+# Size (int)
+DB_POOL_SIZE=10
+`, string(files["database.env"]))
+	assert.Equal(t, "# Header\n\n## Cache\n\n# TTL (string)\nCACHE_TTL=1m\n", string(files["cache.env"]))
+	assert.Equal(t, `# Header
+
+## Cache
+
+# Peer (string)
+CACHE_PEER=
+
+## Queue
+
+# URL (string)
+QUEUE_URL=
+`, string(files["cluster.env"]))
+	assert.Equal(t, `# .env
+NAME
+
+# cache.env
+CACHE_TTL
+
+# cluster.env
+CACHE_PEER
+QUEUE_URL
+
+# database.env
+DB_HOST
+DB_POOL_SIZE
+`, string(files[".env.index"]))
+}
+
+func TestExportFilesByTag(t *testing.T) {
+	files, err := New(WithHeaderText("")).ExportFiles(new(testConfigSplit))
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Len(t, files, 3)
+	assert.Contains(t, string(files[".env"]), "DB_POOL_SIZE=10\n")
+	assert.NotContains(t, string(files[".env"]), "QUEUE_URL")
+	assert.Equal(t, "# .env\nNAME\nDB_HOST\nDB_POOL_SIZE\nCACHE_TTL\n\n# cluster.env\nCACHE_PEER\nQUEUE_URL\n",
+		string(files[".env.index"]))
+
+	// nothing to split
+	type config struct {
+		A string `env:"A"`
+	}
+	files, err = New(WithSplitByGroup(true)).ExportFiles(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+	data, err := New().Export(new(config))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, map[string][]byte{".env": data}, files)
+}
+
+func TestToFileSplit(t *testing.T) {
+	dir := t.TempDir()
+	e := New(WithExportedFileName(filepath.Join(dir, ".env")), WithSplitByGroup(true))
+
+	if err := e.ToFile(new(testConfigSplit)); err != nil {
+		t.Error(err)
+	}
+
+	for _, name := range []string{".env", ".env.index", "database.env", "cache.env", "cluster.env"} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		assert.NoError(t, err, name)
+	}
+}
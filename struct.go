@@ -0,0 +1,81 @@
+package cfg2env
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Struct is a static description of configuration struct.
+// It can be exported instead of configuration object when
+// struct type is not available at runtime, e.g. when it is
+// loaded from source code (see cmd/cfg2env).
+type Struct struct {
+	Doc    string // documentation, used in group header of nested struct
+	Fields []Field
+}
+
+// Field is a static description of single struct field
+type Field struct {
+	Name   string  // field name
+	Type   string  // type name as displayed in variable description
+	Kind   string  // underlying type using builtin types, e.g. []string for []Level
+	Tag    string  // raw struct tag
+	Doc    string  // documentation, used if field has no description tag
+	Struct *Struct // nested struct description, nil for non-struct fields
+}
+
+// describeCfg exports struct description in structured format,
+// same way reflectCfg does it for configuration objects
+func (e *Exporter) describeCfg(s *Struct, prefix string) []cfgItem {
+	exported := e.newLevel()
+
+	for _, field := range s.Fields {
+		fieldPath := prefix + field.Name
+
+		// skip unexported
+		if r, _ := utf8.DecodeRuneInString(field.Name); !unicode.IsUpper(r) {
+			continue
+		}
+
+		// skip excluded fields
+		if e.isExcluded(field.Name) || e.isSkipped(MultilineStructTag(field.Tag)) ||
+			e.isFiltered(fieldPath, field.Type, MultilineStructTag(field.Tag), field.Struct == nil) {
+			continue
+		}
+
+		exported.addField(e.checkTag(fieldPath, MultilineStructTag(field.Tag))...)
+
+		if field.Struct != nil {
+			if nested := e.describeCfg(field.Struct, fieldPath+"."); e.keepGroup(nested) {
+				exported.addGroup(
+					e.groupItem(fieldPath, MultilineStructTag(field.Tag), docComment(field.Struct.Doc)),
+					nested...,
+				)
+			}
+			continue
+		}
+
+		kind := field.Kind
+		if len(kind) == 0 {
+			kind = field.Type
+		}
+
+		exported.addField(e.fieldItems(fieldInfo{
+			name:     field.Name,
+			typeName: field.Type,
+			kind:     kind,
+			path:     fieldPath,
+			tag:      MultilineStructTag(field.Tag),
+			doc:      docComment(field.Doc),
+		})...)
+	}
+
+	return exported.items()
+}
+
+// docComment prepares documentation text to be used in comments,
+// continuation lines are indented same way as multi-line tags are
+func docComment(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n ")
+}
@@ -0,0 +1,86 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportStruct(t *testing.T) {
+	s := &Struct{
+		Fields: []Field{
+			{Name: "RWMutex", Type: "sync.RWMutex", Struct: &Struct{}},
+			{Name: "A", Type: "string", Tag: `env:"A" default:"a" desc:"descA"`},
+			{Name: "unexported", Type: "string", Tag: `env:"UNEXPORTED"`},
+			{Name: "NoEnv", Type: "string"},
+			{Name: "Inner", Type: "struct{...}", Struct: &Struct{
+				Fields: []Field{
+					{Name: "X", Type: "time.Duration", Tag: `env:"X" default:"5s"`},
+				},
+			}},
+		},
+	}
+
+	e := New(WithHeaderText(""))
+
+	d, err := e.Export(s)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# A (string) descA\nA=a\n\n## Inner\n\n# X (time.Duration)\nX=5s\n"
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportStruct_MatchesReflection(t *testing.T) {
+	s := &Struct{
+		Fields: []Field{
+			{Name: "Outer", Type: "string", Tag: `env:"OUTER" default:"outer"`},
+			{Name: "Inner", Type: "struct { X string; Y int }", Struct: &Struct{
+				Fields: []Field{
+					{Name: "X", Type: "string", Tag: `env:"X" default:"x"`},
+					{Name: "Y", Type: "int", Tag: `env:"Y" default:"7"`},
+				},
+			}},
+		},
+	}
+
+	e := New()
+
+	expected, err := e.Export(new(nestedStruct))
+	if err != nil {
+		t.Error(err)
+	}
+
+	d, err := e.Export(s)
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, string(expected), string(d))
+}
+
+func TestExportStruct_Doc(t *testing.T) {
+	s := &Struct{
+		Fields: []Field{
+			{Name: "A", Type: "string", Tag: `env:"A"`, Doc: "A is documented\nwith two lines\n"},
+			{Name: "B", Type: "string", Tag: `env:"B" desc:"descB"`, Doc: "B doc is ignored"},
+			{Name: "Inner", Type: "config.Inner", Struct: &Struct{
+				Doc: "Inner is nested group\n",
+				Fields: []Field{
+					{Name: "X", Type: "string", Tag: `env:"X"`},
+				},
+			}},
+		},
+	}
+
+	e := New(WithHeaderText(""))
+
+	d, err := e.Export(s)
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := "# A (string) A is documented\n# with two lines\nA=\n# B (string) descB\nB=\n\n## Inner\n# Inner is nested group\n\n# X (string)\nX=\n"
+	assert.Equal(t, expected, string(d))
+}
@@ -0,0 +1,57 @@
+package cfg2env
+
+import (
+	"strings"
+)
+
+// ExportSystemd exports struct as systemd EnvironmentFile,
+// values are quoted and escaped according to systemd rules
+func (e *Exporter) ExportSystemd(cfg interface{}) ([]byte, error) {
+	return e.exportSystemd(cfg, make([]string, 0), func(name string, value string) string {
+		return name + "=" + systemdEnvironmentFileValue(value)
+	})
+}
+
+// ExportSystemdDropIn exports struct as systemd unit drop-in
+// with `[Service]` section containing `Environment=` lines
+func (e *Exporter) ExportSystemdDropIn(cfg interface{}) ([]byte, error) {
+	return e.exportSystemd(cfg, []string{"[Service]"}, func(name string, value string) string {
+		return "Environment=" + systemdEnvironmentValue(name+"="+value)
+	})
+}
+
+func (e *Exporter) exportSystemd(
+	cfg interface{}, lines []string, assignment func(name string, value string) string,
+) ([]byte, error) {
+	vars, err := e.variables(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	lines = append(e.headerLines(), lines...)
+	lines = append(lines, e.extraEntryLines(func(name string, value string) []string {
+		return []string{assignment(name, value)}
+	})...)
+	lines = append(lines, e.variableLines(vars, func(v variable) []string {
+		return []string{assignment(v.envVarName, v.defValue)}
+	})...)
+
+	return writeLines(lines)
+}
+
+// systemdEnvironmentFileValue quotes value if it contains whitespace,
+// quotes or characters which have special meaning in EnvironmentFile
+func systemdEnvironmentFileValue(s string) string {
+	if !strings.ContainsAny(s, " \t\r\n\"'\\#;$`") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// systemdEnvironmentValue quotes whole assignment of Environment= directive,
+// escaping specifiers and using C-style escapes for special characters
+func systemdEnvironmentValue(s string) string {
+	r := strings.NewReplacer(`%`, `%%`, `\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`, "\r", `\r`)
+	return `"` + r.Replace(s) + `"`
+}
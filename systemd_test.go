@@ -0,0 +1,71 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfigSystemd struct {
+	Plain  string `env:"PLAIN" default:"value" desc:"Plain value"`
+	Spaces string `env:"SPACES" default:"with spaces and 100%"`
+	Nested struct {
+		Special string `env:"SPECIAL" default:"$HOME;#"`
+		Empty   string `env:"EMPTY"`
+	}
+}
+
+func TestExportSystemd(t *testing.T) {
+	e := New(
+		WithHeaderText("# Test Header"),
+		WithExtraEntry("ESCAPED", `"\`),
+	)
+
+	d, err := e.ExportSystemd(new(testConfigSystemd))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `# Test Header
+
+# Extra pre-declared entries
+ESCAPED="\"\\"
+# Plain (string) Plain value
+PLAIN=value
+# Spaces (string)
+SPACES="with spaces and 100%"
+## Nested
+# Special (string)
+SPECIAL="\$HOME;#"
+# Empty (string)
+EMPTY=
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestExportSystemdDropIn(t *testing.T) {
+	e := New(
+		WithHeaderText(""),
+		WithExtraEntry("ESCAPED", "\"\\\n"),
+	)
+
+	d, err := e.ExportSystemdDropIn(new(testConfigSystemd))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `[Service]
+# Extra pre-declared entries
+Environment="ESCAPED=\"\\\n"
+# Plain (string) Plain value
+Environment="PLAIN=value"
+# Spaces (string)
+Environment="SPACES=with spaces and 100%%"
+## Nested
+# Special (string)
+Environment="SPECIAL=$HOME;#"
+# Empty (string)
+Environment="EMPTY="
+`
+	assert.Equal(t, expected, string(d))
+}
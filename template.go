@@ -0,0 +1,129 @@
+package cfg2env
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate is template of default .env layout:
+// header, extra entries and variables with description comments
+// separated into groups by headers of nested structs
+const DefaultTemplate = `
+{{- if .Header -}}
+{{ .Header }}
+
+{{ end -}}
+
+{{- if .Extra -}}
+# Extra pre-declared entries
+{{ range .Extra }}{{ .Name }}={{ .Value }}
+{{ end -}}
+{{- if .Items }}
+{{ end -}}
+{{- end -}}
+
+{{- $variable := false -}}
+{{- range .Items -}}
+{{- if .Group -}}
+{{- if $variable }}
+{{ end -}}
+{{ group .Comment }}
+
+{{ $variable = false -}}
+{{- else if .Name -}}
+{{ if .Disabled }}# {{ end }}{{ .Name }}={{ quote .Value }}
+{{ $variable = true -}}
+{{- else -}}
+{{ comment .Comment }}
+{{ end -}}
+{{- end -}}
+`
+
+// TemplateModel is data passed to template
+type TemplateModel struct {
+	Header string          // header text
+	Extra  []TemplateEntry // extra pre-declared entries
+	Items  []TemplateItem  // walked configuration
+}
+
+// TemplateEntry is extra pre-declared entry
+type TemplateEntry struct {
+	Name  string
+	Value string
+}
+
+// TemplateItem is single item of walked configuration,
+// it is either nested group header, comment or variable definition
+type TemplateItem struct {
+	Group    bool   // item is header of nested group
+	Resumed  bool   // group header is repeated after nested groups
+	Comment  string // group header or comment text, empty for variables
+	Name     string // variable name, empty for groups and comments
+	Value    string // default value
+	Path     string // path of struct field
+	Secret   bool   // variable is marked as secret
+	Required bool   // variable is marked as required
+	Disabled bool   // condition of variable is not met, see WithCommentOutDisabled
+
+	Deprecated string   // deprecation note
+	Aliases    []string // former names of variable
+}
+
+// templateModel converts exported items to template data
+func (e *Exporter) templateModel(items []cfgItem) TemplateModel {
+	model := TemplateModel{
+		Header: e.headerText,
+		Extra:  make([]TemplateEntry, 0, len(e.extraEntries)),
+		Items:  make([]TemplateItem, 0, len(items)),
+	}
+	for _, k := range e.extraEntryKeys() {
+		model.Extra = append(model.Extra, TemplateEntry{Name: k, Value: e.extraEntryValue(k)})
+	}
+	if e.omitEmptyGroups {
+		items = omitEmptyGroups(items)
+	}
+	for i := range items {
+		model.Items = append(model.Items, TemplateItem{
+			Group:    items[i].nestedGroup,
+			Resumed:  items[i].resumed,
+			Comment:  items[i].comment,
+			Name:     items[i].envVarName,
+			Value:    items[i].defValue,
+			Path:     items[i].fieldPath,
+			Secret:   items[i].secret,
+			Required: items[i].required,
+			Disabled: items[i].disabled,
+
+			Deprecated: items[i].deprecated,
+			Aliases:    items[i].aliases,
+		})
+	}
+	return model
+}
+
+// templateFuncs returns helper functions available in templates:
+//
+//	quote   - quotes value if it contains spaces
+//	comment - formats text as comment, respecting comment options
+//	group   - formats text as group header comment
+//	upper   - converts text to upper case
+//	lower   - converts text to lower case
+func (e *Exporter) templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"quote": func(s string) string {
+			if strings.Contains(s, " ") {
+				return fmt.Sprintf("\"%s\"", s)
+			}
+			return s
+		},
+		"comment": func(s string) string {
+			return e.comment(s, false)
+		},
+		"group": func(s string) string {
+			return e.comment(s, true)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+}
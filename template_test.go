@@ -0,0 +1,61 @@
+package cfg2env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate(t *testing.T) {
+	e := New(
+		WithHeaderText("Test Header"),
+		WithExtraEntry("COMPOSE_PROJECT_NAME", "cfg2env"),
+		WithTemplate(`### {{ upper .Header }}
+{{ range .Extra }}{{ .Name }}={{ .Value }}
+{{ end -}}
+{{ range .Items }}{{ if .Group }}[{{ lower .Comment }}]
+{{ else if .Name }}{{ .Name }}={{ quote .Value }}{{ if .Secret }} # secret{{ end }}
+{{ end }}{{ end -}}
+`),
+	)
+
+	d, err := e.Export(new(testConfigSecret))
+	if err != nil {
+		t.Error(err)
+	}
+
+	expected := `### TEST HEADER
+COMPOSE_PROJECT_NAME=cfg2env
+DB_HOST=localhost
+DB_PASSWORD=qwerty # secret
+[cache]
+CACHE_TTL=60
+CACHE_TOKEN=token # secret
+`
+	assert.Equal(t, expected, string(d))
+}
+
+func TestTemplate_Helpers(t *testing.T) {
+	e := New(
+		WithCommentWidth(12),
+		WithTemplate(`{{ quote "a b" }}|{{ quote "ab" }}
+{{ comment "long comment text" }}
+{{ group "Nested" }}
+`),
+	)
+
+	d, err := e.Export(&struct{}{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	assert.Equal(t, "\"a b\"|ab\n# long\n# comment\n# text\n## Nested\n", string(d))
+}
+
+func TestTemplate_Errors(t *testing.T) {
+	_, err := New(WithTemplate(`{{ .Unclosed `)).Export(&struct{}{})
+	assert.ErrorContains(t, err, "failed to parse template")
+
+	_, err = New(WithTemplate(`{{ .Missing }}`)).Export(&struct{}{})
+	assert.ErrorContains(t, err, "failed to execute template")
+}